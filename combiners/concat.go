@@ -0,0 +1,58 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type concat struct {
+	context stream.Context
+}
+
+func Concat() stream.Combiner {
+	return &concat{}
+}
+
+func (combiner *concat) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *concat) Combine(in ...stream.Readable) stream.Readable {
+	max := func(rs ...stream.Readable) int {
+		max := 0
+		for _, r := range rs {
+			capacity := r.Capacity()
+			if max < capacity {
+				max = capacity
+			}
+		}
+		return max
+	}
+
+	reader, writer := stream.New(max(in...))
+
+	go func() {
+		defer combiner.context.Recover()
+		defer close(writer)
+
+		for _, readable := range in {
+			for more := true; more; {
+				var data stream.T
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-combiner.context.Done():
+					return
+				case <-time.After(combiner.context.Deadline()):
+					panic(stream.Timeout)
+				case data, more = <-readable:
+					if more {
+						writer <- data
+					}
+				}
+			}
+		}
+	}()
+
+	return reader
+}
@@ -0,0 +1,56 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestConcat(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a slow but finite stream of data followed by a ready stream", func() {
+			in1, out1 := stream.New(2)
+			go func() {
+				defer close(out1)
+				for i := 1; i <= 3; i++ {
+					time.Sleep(10 * time.Millisecond)
+					out1 <- i
+				}
+			}()
+
+			in2, out2 := stream.New(2)
+			out2 <- 4
+			out2 <- 5
+			close(out2)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.Concat()
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+
+				Convey("Then the streams are emitted end to end in order", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(nil)
+
+				Convey("And I apply the combiner to the streams", func() {
+					combiner := combiners.Concat()
+					combiner.Attach(context)
+					combined := combiner.Combine(in1, in2)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(combined.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 )
 
+// Reads from all streams concurrently emitting
+// items as soon as they become available
 type fifo struct {
 	context stream.Context
 }
@@ -29,21 +31,28 @@ func (combiner *fifo) Combine(in ...stream.Readable) stream.Readable {
 
 	var wg sync.WaitGroup
 	reader, writer := stream.New(capacity(in...))
+	emitter := stream.NewEmitter(combiner.context, writer)
 
 	for _, r := range in {
 		wg.Add(1)
 		go func(r stream.Readable) {
-			defer combiner.context.Recover()
+			// Failures must be recorded before the writer gets closed
 			defer wg.Done()
+			defer combiner.context.Recover()
 
-			select {
-			case <-combiner.context.Failure():
-				return
-			case <-time.After(combiner.context.Deadline()):
-				panic(stream.Timeout)
-			default:
-				for data := range r {
-					writer <- data
+			for {
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-combiner.context.Done():
+					return
+				case <-time.After(combiner.context.Deadline()):
+					panic(stream.Timeout)
+				case data, more := <-r:
+					if !more {
+						return
+					}
+					emitter.Emit(data)
 				}
 			}
 		}(r)
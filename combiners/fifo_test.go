@@ -52,5 +52,21 @@ func TestFifo(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("And a live stream of data that never ends", func() {
+			in, _ := stream.New(0)
+
+			Convey("When the context fails while combining", func() {
+				combiner := combiners.FIFO()
+				combiner.Attach(context)
+				combined := combiner.Combine(in)
+				context.Close(stream.Timeout)
+
+				Convey("Then the combined stream is closed", func() {
+					So(combined.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, stream.Timeout)
+				})
+			})
+		})
 	})
 }
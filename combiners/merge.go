@@ -0,0 +1,10 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+)
+
+// Same as FIFO
+func Merge() stream.Combiner {
+	return FIFO()
+}
@@ -0,0 +1,86 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+// K-way merge of streams already sorted by the given function,
+// ties are resolved in favor of the stream given first
+type mergeSorted struct {
+	context stream.Context
+	fn      stream.SortByFn
+}
+
+func MergeSortedBy(fn stream.SortByFn) stream.Combiner {
+	return &mergeSorted{
+		fn: fn,
+	}
+}
+
+func (combiner *mergeSorted) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *mergeSorted) Combine(in ...stream.Readable) stream.Readable {
+	max := func(rs ...stream.Readable) int {
+		max := 0
+		for _, r := range rs {
+			capacity := r.Capacity()
+			if max < capacity {
+				max = capacity
+			}
+		}
+		return max
+	}
+
+	reader, writer := stream.New(max(in...))
+	emitter := stream.NewEmitter(combiner.context, writer)
+
+	go func() {
+		defer combiner.context.Recover()
+		defer close(writer)
+
+		heads := make([]stream.T, len(in))
+		pending := make([]bool, len(in))
+		open := make([]bool, len(in))
+		for i := range in {
+			open[i] = true
+		}
+
+		for {
+			for i, readable := range in {
+				if !open[i] || pending[i] {
+					continue
+				}
+
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-combiner.context.Done():
+					return
+				case <-time.After(combiner.context.Deadline()):
+					panic(stream.Timeout)
+				case data, more := <-readable:
+					heads[i], pending[i], open[i] = data, more, more
+				}
+			}
+
+			min := -1
+			for i := range heads {
+				if pending[i] && (min < 0 || combiner.fn(heads[i], heads[min])) {
+					min = i
+				}
+			}
+
+			if min < 0 {
+				return
+			}
+
+			emitter.Emit(heads[min])
+			heads[min], pending[min] = nil, false
+		}
+	}()
+
+	return reader
+}
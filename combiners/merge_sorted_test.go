@@ -0,0 +1,63 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestMergeSortedBy(t *testing.T) {
+	byKey := func(a, b stream.T) bool { return a.([]stream.T)[0].(int) < b.([]stream.T)[0].(int) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a few sorted streams of data", func() {
+			in1, out1 := stream.New(3)
+			out1 <- []stream.T{1, "a"}
+			out1 <- []stream.T{2, "a"}
+			out1 <- []stream.T{5, "a"}
+			close(out1)
+
+			in2, out2 := stream.New(2)
+			out2 <- []stream.T{2, "b"}
+			out2 <- []stream.T{3, "b"}
+			close(out2)
+
+			in3, out3 := stream.New(0)
+			close(out3)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.MergeSortedBy(byKey)
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2, in3)
+
+				Convey("Then items are emitted in global order keeping ties in stream order", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{1, "a"},
+						[]stream.T{2, "a"},
+						[]stream.T{2, "b"},
+						[]stream.T{3, "b"},
+						[]stream.T{5, "a"},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(nil)
+
+				Convey("And I apply the combiner to the streams", func() {
+					combiner := combiners.MergeSortedBy(byKey)
+					combiner.Attach(context)
+					combined := combiner.Combine(in1, in2, in3)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(combined.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,69 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a slow stream of data and a fast one", func() {
+			in1, out1 := stream.New(2)
+			go func() {
+				defer close(out1)
+				time.Sleep(30 * time.Millisecond)
+				out1 <- 1
+			}()
+
+			in2, out2 := stream.New(2)
+			out2 <- 2
+			out2 <- 3
+			close(out2)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.Merge()
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+
+				Convey("Then items are emitted as they become available", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{2, 3, 1})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(nil)
+
+				Convey("And I apply the combiner to the streams", func() {
+					combiner := combiners.Merge()
+					combiner.Attach(context)
+					combined := combiner.Combine(in1, in2)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(combined.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And a live stream of data that never ends", func() {
+			in, _ := stream.New(0)
+
+			Convey("When I close the context while merging", func() {
+				combiner := combiners.Merge()
+				combiner.Attach(context)
+				combined := combiner.Combine(in)
+				context.Close(nil)
+
+				Convey("Then the combined stream is closed", func() {
+					So(combined.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,116 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"reflect"
+	"time"
+)
+
+type priority struct {
+	context stream.Context
+}
+
+// Priority combines streams in the given priority order, always emitting
+// data available in higher priority streams before reading from the lower
+// priority ones.
+func Priority() stream.Combiner {
+	return &priority{}
+}
+
+func (combiner *priority) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *priority) Combine(in ...stream.Readable) stream.Readable {
+	capacity := func(rs ...stream.Readable) int {
+		capacity := 0
+		for _, r := range rs {
+			capacity += r.Capacity()
+		}
+		return capacity
+	}
+
+	reader, writer := stream.New(capacity(in...))
+
+	go func() {
+		defer combiner.context.Recover()
+		defer close(writer)
+
+		closed := make([]bool, len(in))
+		remaining := len(in)
+
+		closeStream := func(i int) {
+			closed[i] = true
+			remaining--
+		}
+
+		// Non-blocking read from the highest priority stream with data available
+		poll := func() (stream.T, bool) {
+			for i, readable := range in {
+				if closed[i] {
+					continue
+				}
+
+				select {
+				case data, more := <-readable:
+					if !more {
+						closeStream(i)
+						continue
+					}
+					return data, true
+				default:
+				}
+			}
+			return nil, false
+		}
+
+		for remaining > 0 {
+			select {
+			case <-combiner.context.Failure():
+				return
+			case <-combiner.context.Done():
+				return
+			default:
+			}
+
+			if data, ok := poll(); ok {
+				writer <- data
+				continue
+			}
+
+			if remaining == 0 {
+				return
+			}
+
+			// No data is available at the moment so wait on all open streams
+			cases := []reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(combiner.context.Failure())},
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(combiner.context.Done())},
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(time.After(combiner.context.Deadline()))},
+			}
+			indexes := []int{}
+			for i, readable := range in {
+				if !closed[i] {
+					cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(readable)})
+					indexes = append(indexes, i)
+				}
+			}
+
+			chosen, data, more := reflect.Select(cases)
+			switch chosen {
+			case 0, 1:
+				return
+			case 2:
+				panic(stream.Timeout)
+			default:
+				if !more {
+					closeStream(indexes[chosen-3])
+					continue
+				}
+				writer <- data.Interface()
+			}
+		}
+	}()
+
+	return reader
+}
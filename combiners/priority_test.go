@@ -0,0 +1,76 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestPriority(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a high and a low priority stream with data available", func() {
+			high, highOut := stream.New(2)
+			highOut <- "h1"
+			highOut <- "h2"
+			close(highOut)
+
+			low, lowOut := stream.New(2)
+			lowOut <- "l1"
+			lowOut <- "l2"
+			close(lowOut)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.Priority()
+				combiner.Attach(context)
+				combined := combiner.Combine(high, low)
+
+				Convey("Then high priority items are emitted first", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{"h1", "h2", "l1", "l2"})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the combiner to the streams", func() {
+					combiner := combiners.Priority()
+					combiner.Attach(context)
+					combined := combiner.Combine(high, low)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(combined.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And a slow high priority stream", func() {
+			high, highOut := stream.New(1)
+			go func() {
+				defer close(highOut)
+				time.Sleep(50 * time.Millisecond)
+				highOut <- "h1"
+			}()
+
+			low, lowOut := stream.New(2)
+			lowOut <- "l1"
+			lowOut <- "l2"
+			close(lowOut)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.Priority()
+				combiner.Attach(context)
+				combined := combiner.Combine(high, low)
+
+				Convey("Then low priority items are not starved", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{"l1", "l2", "h1"})
+				})
+			})
+		})
+	})
+}
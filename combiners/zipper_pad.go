@@ -0,0 +1,74 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type zipPad struct {
+	context stream.Context
+	fill    stream.T
+}
+
+func ZipPad(fill stream.T) stream.Combiner {
+	return &zipPad{
+		fill: fill,
+	}
+}
+
+func (combiner *zipPad) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *zipPad) Combine(in ...stream.Readable) stream.Readable {
+	capacity := func(rs ...stream.Readable) int {
+		capacity := 0
+		for _, r := range rs {
+			capacity += r.Capacity()
+		}
+		return capacity
+	}
+
+	reader, writer := stream.New(capacity(in...))
+
+	go func() {
+		defer combiner.context.Recover()
+		defer close(writer)
+
+		doneIndexes := make(map[int]bool)
+		for len(doneIndexes) < len(in) {
+			select {
+			case <-combiner.context.Failure():
+				return
+			case <-time.After(combiner.context.Deadline()):
+				panic(stream.Timeout)
+			default:
+				zipped := make([]stream.T, len(in))
+				for i, readable := range in {
+					zipped[i] = combiner.fill
+					if doneIndexes[i] {
+						continue
+					}
+
+					data, opened := <-readable
+					if !opened {
+						doneIndexes[i] = true
+						continue
+					}
+
+					zipped[i] = data
+				}
+
+				if len(doneIndexes) == len(in) {
+					return
+				}
+
+				for _, data := range zipped {
+					writer <- data
+				}
+			}
+		}
+	}()
+
+	return reader
+}
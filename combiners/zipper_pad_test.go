@@ -0,0 +1,73 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestZipperPad(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And streams of data with the same length", func() {
+			in1, out1 := stream.New(2)
+			out1 <- 1
+			out1 <- 2
+			close(out1)
+
+			in2, out2 := stream.New(2)
+			out2 <- "a"
+			out2 <- "b"
+			close(out2)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.ZipPad(nil)
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+
+				Convey("Then the result is the same as a regular zip", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{1, "a", 2, "b"})
+				})
+			})
+		})
+
+		Convey("And streams of data with different lengths", func() {
+			in1, out1 := stream.New(3)
+			out1 <- 1
+			out1 <- 2
+			out1 <- 3
+			close(out1)
+
+			in2, out2 := stream.New(1)
+			out2 <- "a"
+			close(out2)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.ZipPad(nil)
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+
+				Convey("Then the exhausted streams are padded with the fill value", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{1, "a", 2, nil, 3, nil})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the combiner to the streams", func() {
+					combiner := combiners.ZipPad(nil)
+					combiner.Attach(context)
+					combined := combiner.Combine(in1, in2)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(combined.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -9,6 +9,7 @@ import (
 var (
 	ErrNoSuchPointer      = errors.New("Element is not a pointer")
 	ErrNoSuchSlicePointer = errors.New("Element is not a pointer to a slice")
+	ErrNoSuchMapPointer   = errors.New("Element is not a pointer to a map")
 )
 
 func Drainer() stream.Consumer {
@@ -30,6 +31,28 @@ func ItemsCollector(dst interface{}) stream.Consumer {
 	}
 }
 
+// Collects items into the map pointed by dst keyed by the result of fn,
+// later items replacing earlier ones sharing the same key. The map is
+// allocated in case it is nil.
+func MapCollector(fn stream.MapFn, dst interface{}) stream.Consumer {
+	ptr := reflect.ValueOf(dst)
+
+	if ptr.Kind() != reflect.Ptr || ptr.Elem().Kind() != reflect.Map {
+		panic(ErrNoSuchMapPointer)
+	}
+
+	container := ptr.Elem()
+	if container.IsNil() {
+		container.Set(reflect.MakeMap(container.Type()))
+	}
+
+	return &Sink{
+		OnNext: func(data stream.T) {
+			container.SetMapIndex(reflect.ValueOf(fn(data)), reflect.ValueOf(data))
+		},
+	}
+}
+
 func LastItemCollector(dst interface{}) stream.Consumer {
 	ptr := reflect.ValueOf(dst)
 
@@ -51,6 +74,13 @@ func CollectBy(fn stream.EachFn) stream.Consumer {
 	}
 }
 
+func ParallelEach(workers int, fn stream.EachFn) stream.Consumer {
+	return &parallelSink{
+		workers: workers,
+		OnNext:  fn,
+	}
+}
+
 func GroupBy(fn stream.MapFn, result stream.Groups) stream.Consumer {
 	return &Sink{
 		OnNext: func(data stream.T) {
@@ -0,0 +1,81 @@
+package consumers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"io"
+)
+
+type csvSink struct {
+	context stream.Context
+	writer  io.Writer
+	csv     *csv.Writer
+	headers []string
+}
+
+// Writes each item as a CSV record into w, preceded by the given headers
+// if any. Items must be either []string or map[string]string, the latter
+// having its fields written in the headers order. w is closed once the
+// stream is consumed in case it implements io.Closer.
+func ToCSV(w io.Writer, headers []string) stream.Consumer {
+	return &csvSink{
+		writer:  w,
+		csv:     csv.NewWriter(w),
+		headers: headers,
+	}
+}
+
+func (sink *csvSink) Attach(context stream.Context) {
+	sink.context = context
+}
+
+func (sink *csvSink) Consume(in stream.Readable) {
+	defer sink.context.Recover()
+	defer sink.finish()
+
+	if len(sink.headers) > 0 {
+		sink.write(sink.headers)
+	}
+
+	writer := &Sink{OnNext: sink.write}
+	writer.Attach(sink.context)
+	writer.Consume(in)
+}
+
+func (sink *csvSink) write(data stream.T) {
+	var record []string
+
+	switch data := data.(type) {
+	case []string:
+		record = data
+	case map[string]string:
+		if len(sink.headers) == 0 {
+			panic(fmt.Errorf("csv: headers are required to write %v", data))
+		}
+
+		record = make([]string, len(sink.headers))
+		for i, header := range sink.headers {
+			record[i] = data[header]
+		}
+	default:
+		panic(fmt.Errorf("csv: cannot write %T as a record", data))
+	}
+
+	if err := sink.csv.Write(record); err != nil {
+		panic(err)
+	}
+}
+
+func (sink *csvSink) finish() {
+	sink.csv.Flush()
+	if err := sink.csv.Error(); err != nil {
+		panic(err)
+	}
+
+	if closer, ok := sink.writer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package consumers_test
+
+import (
+	"bytes"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestToCSV(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of records", func() {
+			in, out := stream.New(2)
+			out <- []string{"Diego", "a \"quoted\" value"}
+			out <- map[string]string{"age": "30", "name": "Borges, Jr"}
+			close(out)
+
+			Convey("When I apply the csv consumer with headers", func() {
+				writer := &closableBuffer{}
+				consumer := consumers.ToCSV(writer, []string{"name", "age"})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then records are written as csv in the headers order", func() {
+					So(writer.String(), ShouldEqual, "name,age\nDiego,\"a \"\"quoted\"\" value\"\n\"Borges, Jr\",30\n")
+
+					Convey("And the writer is closed", func() {
+						So(writer.closed, ShouldBeTrue)
+					})
+				})
+			})
+
+			Convey("When I apply the csv consumer without headers", func() {
+				var buffer bytes.Buffer
+				consumer := consumers.ToCSV(&buffer, nil)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(context.Err(), ShouldNotBeNil)
+				})
+			})
+		})
+
+		Convey("And a stream of unsupported items", func() {
+			in, out := stream.New(1)
+			out <- 1
+			close(out)
+
+			Convey("When I apply the csv consumer", func() {
+				var buffer bytes.Buffer
+				consumer := consumers.ToCSV(&buffer, nil)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(context.Err(), ShouldNotBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,134 @@
+package consumers
+
+import (
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type FileOptions struct {
+	// Defaults to EncodeLines
+	Encode stream.EncodeFn
+	// Rotates the file once writing an item would exceed the given
+	// size in bytes. Zero disables size based rotation.
+	MaxSize int64
+	// Syncs the file to disk every n items. Zero only syncs
+	// the file upon rotation.
+	SyncEvery int
+}
+
+// Writes items into files named after the given pattern, having its base
+// name formatted with time.Format, rotating files whenever the formatted
+// name changes or the max size is reached. Files are written under a .tmp
+// suffix and renamed once rotated, having size rotated files suffixed with
+// a sequence number.
+type fileSink struct {
+	context stream.Context
+	pattern string
+	options FileOptions
+	file    *os.File
+	name    string
+	size    int64
+	writes  int
+}
+
+func ToFile(pattern string, options FileOptions) stream.Consumer {
+	if options.Encode == nil {
+		options.Encode = EncodeLines
+	}
+
+	return &fileSink{
+		pattern: pattern,
+		options: options,
+	}
+}
+
+func (sink *fileSink) Attach(context stream.Context) {
+	sink.context = context
+}
+
+func (sink *fileSink) Consume(in stream.Readable) {
+	defer sink.context.Recover()
+	defer sink.rotate()
+
+	writer := &Sink{OnNext: sink.write}
+	writer.Attach(sink.context)
+	writer.Consume(in)
+}
+
+func (sink *fileSink) write(data stream.T) {
+	bytes, err := sink.options.Encode(data)
+	if err != nil {
+		panic(err)
+	}
+
+	name := filepath.Join(filepath.Dir(sink.pattern), time.Now().Format(filepath.Base(sink.pattern)))
+	exceeds := sink.options.MaxSize > 0 && sink.size > 0 && sink.size+int64(len(bytes)) > sink.options.MaxSize
+	if sink.file != nil && (name != sink.name || exceeds) {
+		sink.rotate()
+	}
+
+	if sink.file == nil {
+		sink.open(name)
+	}
+
+	n, err := sink.file.Write(bytes)
+	sink.size += int64(n)
+	if err != nil {
+		panic(err)
+	}
+
+	sink.writes++
+	if sink.options.SyncEvery > 0 && sink.writes%sink.options.SyncEvery == 0 {
+		if err := sink.file.Sync(); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (sink *fileSink) open(name string) {
+	file, err := os.OpenFile(name+".tmp", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		panic(err)
+	}
+
+	sink.file = file
+	sink.name = name
+	sink.size = 0
+}
+
+// Syncs and closes the current file, if any, atomically
+// renaming it to its final name
+func (sink *fileSink) rotate() {
+	if sink.file == nil {
+		return
+	}
+
+	file := sink.file
+	sink.file = nil
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		panic(err)
+	}
+
+	if err := file.Close(); err != nil {
+		panic(err)
+	}
+
+	target := sink.name
+	for i := 1; exists(target); i++ {
+		target = fmt.Sprintf("%v.%v", sink.name, i)
+	}
+
+	if err := os.Rename(file.Name(), target); err != nil {
+		panic(err)
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
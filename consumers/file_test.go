@@ -0,0 +1,77 @@
+package consumers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestToFile(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+		dir, _ := ioutil.TempDir("", "rivers")
+		Reset(func() { os.RemoveAll(dir) })
+
+		read := func(name string) string {
+			content, _ := ioutil.ReadFile(filepath.Join(dir, name))
+			return string(content)
+		}
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- "aaa"
+			out <- "bbb"
+			out <- "ccc"
+			out <- "ddd"
+			out <- "eee"
+			close(out)
+
+			Convey("When I apply the file consumer with size based rotation", func() {
+				consumer := consumers.ToFile(filepath.Join(dir, "out.log"), consumers.FileOptions{MaxSize: 8, SyncEvery: 1})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then items are written into rotated files", func() {
+					So(context.Err(), ShouldBeNil)
+					So(read("out.log"), ShouldEqual, "aaa\nbbb\n")
+					So(read("out.log.1"), ShouldEqual, "ccc\nddd\n")
+					So(read("out.log.2"), ShouldEqual, "eee\n")
+
+					Convey("And no temporary file is left behind", func() {
+						tmps, _ := filepath.Glob(filepath.Join(dir, "*.tmp"))
+						So(tmps, ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And a slow stream of data", func() {
+			in, out := stream.New(2)
+			go func() {
+				defer close(out)
+				out <- "first"
+				time.Sleep(1100 * time.Millisecond)
+				out <- "second"
+			}()
+
+			Convey("When I apply the file consumer with a time based pattern", func() {
+				consumer := consumers.ToFile(filepath.Join(dir, "out-15-04-05.log"), consumers.FileOptions{})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then items are written into a file per period", func() {
+					files, _ := filepath.Glob(filepath.Join(dir, "out-*.log"))
+					So(len(files), ShouldEqual, 2)
+					So(read(filepath.Base(files[0])), ShouldEqual, "first\n")
+					So(read(filepath.Base(files[1])), ShouldEqual, "second\n")
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,78 @@
+package consumers
+
+import (
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"net/http"
+	"strings"
+)
+
+type httpResponseSink struct {
+	context stream.Context
+	writer  http.ResponseWriter
+	request *http.Request
+	encode  stream.EncodeFn
+}
+
+// Writes each item encoded by fn into the response as soon as it is
+// consumed, flushing it per item so the response is streamed to the client,
+// e.g. as NDJSON with EncodeJSONLines or as Server-Sent Events with
+// EncodeSSE. The context is closed with the request context error once
+// the client disconnects.
+func ToHTTPResponse(w http.ResponseWriter, r *http.Request, fn stream.EncodeFn) stream.Consumer {
+	if fn == nil {
+		fn = Encode
+	}
+
+	return &httpResponseSink{
+		writer:  w,
+		request: r,
+		encode:  fn,
+	}
+}
+
+// Encodes each item with Encode as a Server-Sent
+// Event having a data field per line
+func EncodeSSE(data stream.T) ([]byte, error) {
+	bytes, err := Encode(data)
+	lines := strings.Replace(string(bytes), "\n", "\ndata: ", -1)
+	return []byte(fmt.Sprintf("data: %v\n\n", lines)), err
+}
+
+func (sink *httpResponseSink) Attach(context stream.Context) {
+	sink.context = context
+}
+
+func (sink *httpResponseSink) Consume(in stream.Readable) {
+	defer sink.context.Recover()
+
+	finished := make(chan struct{})
+	defer close(finished)
+
+	go func() {
+		select {
+		case <-sink.request.Context().Done():
+			sink.context.Close(sink.request.Context().Err())
+		case <-finished:
+		}
+	}()
+
+	writer := &Sink{OnNext: sink.write}
+	writer.Attach(sink.context)
+	writer.Consume(in)
+}
+
+func (sink *httpResponseSink) write(data stream.T) {
+	bytes, err := sink.encode(data)
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err := sink.writer.Write(bytes); err != nil {
+		panic(err)
+	}
+
+	if flusher, ok := sink.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,54 @@
+package consumers_test
+
+import (
+	stdcontext "context"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestToHTTPResponse(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- "a"
+			out <- "multi\nline"
+			close(out)
+
+			Convey("When I apply the http response consumer encoding server sent events", func() {
+				recorder := httptest.NewRecorder()
+				request := httptest.NewRequest("GET", "/events", nil)
+				consumer := consumers.ToHTTPResponse(recorder, request, consumers.EncodeSSE)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then items are written as events and flushed", func() {
+					So(recorder.Body.String(), ShouldEqual, "data: a\n\ndata: multi\ndata: line\n\n")
+					So(recorder.Flushed, ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("And a stream that never ends", func() {
+			in, _ := stream.New(0)
+
+			Convey("When the client disconnects while I apply the http response consumer", func() {
+				ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
+				request := httptest.NewRequest("GET", "/events", nil).WithContext(ctx)
+				consumer := consumers.ToHTTPResponse(httptest.NewRecorder(), request, consumers.EncodeJSONLines)
+				consumer.Attach(context)
+				cancel()
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the request context error", func() {
+					So(context.Err(), ShouldEqual, stdcontext.Canceled)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package consumers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestMapCollector(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- "a"
+			out <- "bb"
+			out <- "cc"
+			close(out)
+
+			byLength := func(data stream.T) stream.T { return len(data.(string)) }
+
+			Convey("When I apply the map collector consumer", func() {
+				var data map[int]string
+				consumer := consumers.MapCollector(byLength, &data)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then data is collected by key, later items replacing earlier ones", func() {
+					So(data, ShouldResemble, map[int]string{1: "a", 2: "cc"})
+				})
+			})
+
+			Convey("When I apply the collector consuming data into a non map pointer", func() {
+				var data map[int]string
+				collect := func() {
+					consumers.MapCollector(byLength, data)
+				}
+
+				Convey("Then it panics", func() {
+					So(collect, ShouldPanicWith, consumers.ErrNoSuchMapPointer)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package consumers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelEach(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(4)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			close(out)
+
+			Convey("When I apply the consumer with as many workers as items", func() {
+				var running, maxRunning int32
+				consumer := consumers.ParallelEach(4, func(data stream.T) {
+					current := atomic.AddInt32(&running, 1)
+					for {
+						max := atomic.LoadInt32(&maxRunning)
+						if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+				})
+				consumer.Attach(context)
+				start := time.Now()
+				consumer.Consume(in)
+
+				Convey("Then items are consumed concurrently", func() {
+					So(time.Since(start), ShouldBeLessThan, 60*time.Millisecond)
+					So(atomic.LoadInt32(&maxRunning), ShouldBeGreaterThan, 1)
+
+					Convey("And the stream is drained", func() {
+						_, opened := <-in
+						So(opened, ShouldBeFalse)
+					})
+				})
+			})
+
+			Convey("When a worker panics", func() {
+				consumer := consumers.ParallelEach(2, func(data stream.T) {
+					panic(errors.New("boom"))
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the failure", func() {
+					So(context.Err(), ShouldResemble, errors.New("boom"))
+				})
+			})
+		})
+	})
+}
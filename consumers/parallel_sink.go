@@ -0,0 +1,38 @@
+package consumers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+)
+
+// Consumes a stream through a bounded number of concurrent sinks
+type parallelSink struct {
+	context stream.Context
+	workers int
+	OnNext  stream.EachFn
+}
+
+func (sink *parallelSink) Attach(context stream.Context) {
+	sink.context = context
+}
+
+func (sink *parallelSink) Consume(in stream.Readable) {
+	workers := sink.workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		worker := &Sink{OnNext: sink.OnNext}
+		worker.Attach(sink.context)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker.Consume(in)
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,153 @@
+package consumers
+
+import (
+	stdcontext "context"
+	"database/sql"
+	"github.com/drborges/rivers/stream"
+	"strings"
+	"time"
+)
+
+type SQLOptions struct {
+	// Number of items inserted per transaction, defaults to 100
+	BatchSize int
+	// Retries batches failing with retryable errors, defaults to
+	// 3 attempts backing off from 50ms. Attempts: 1 disables retries
+	Retry *stream.RetryPolicy
+	// Whether or not a failed batch should be retried, defaults
+	// to errors mentioning a deadlock
+	Retryable func(error) bool
+}
+
+type sqlSink struct {
+	context stream.Context
+	db      *sql.DB
+	stmt    string
+	options SQLOptions
+	report  func(data stream.T, err error)
+	batch   []stream.T
+}
+
+// Executes the given statement for each item within transactions of up to
+// options.BatchSize items. Items of type []interface{} are passed as the
+// statement arguments, any other item as its single argument. Batches failing
+// with retryable errors are retried according to options.Retry. Batches that
+// cannot be inserted are passed to report as a []stream.T along with the
+// error, or fail the pipeline in case report is nil.
+func ToSQL(db *sql.DB, stmt string, options SQLOptions, report func(data stream.T, err error)) stream.Consumer {
+	if options.BatchSize <= 0 {
+		options.BatchSize = 100
+	}
+
+	if options.Retry == nil {
+		options.Retry = &stream.RetryPolicy{Attempts: 3, Backoff: 50 * time.Millisecond}
+	}
+
+	if options.Retryable == nil {
+		options.Retryable = isDeadlock
+	}
+
+	return &sqlSink{
+		db:      db,
+		stmt:    stmt,
+		options: options,
+		report:  report,
+	}
+}
+
+func (sink *sqlSink) Attach(context stream.Context) {
+	sink.context = context
+}
+
+func (sink *sqlSink) Consume(in stream.Readable) {
+	defer sink.context.Recover()
+
+	writer := &Sink{OnNext: func(data stream.T) {
+		sink.batch = append(sink.batch, data)
+		if len(sink.batch) == sink.options.BatchSize {
+			sink.flush()
+		}
+	}}
+	writer.Attach(sink.context)
+	writer.Consume(in)
+
+	select {
+	case <-sink.context.Failure():
+	default:
+		sink.flush()
+	}
+}
+
+func (sink *sqlSink) flush() {
+	if len(sink.batch) == 0 {
+		return
+	}
+
+	batch := sink.batch
+	sink.batch = nil
+
+	for failures := 1; ; failures++ {
+		err := sink.insert(batch)
+		if err == nil {
+			return
+		}
+
+		policy := sink.options.Retry
+		if policy.Allows(failures) && sink.options.Retryable(err) {
+			if sink.wait(policy.Delay(failures)) {
+				continue
+			}
+			return
+		}
+
+		if sink.report == nil {
+			panic(err)
+		}
+
+		sink.report(batch, err)
+		return
+	}
+}
+
+func (sink *sqlSink) insert(batch []stream.T) error {
+	tx, err := sink.db.BeginTx(stdcontext.Background(), nil)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(sink.stmt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, data := range batch {
+		args, ok := data.([]interface{})
+		if !ok {
+			args = []interface{}{data}
+		}
+
+		if _, err := stmt.Exec(args...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Blocks for the given duration returning false in
+// case the context fails in the meantime
+func (sink *sqlSink) wait(duration time.Duration) bool {
+	select {
+	case <-sink.context.Failure():
+		return false
+	case <-time.After(duration):
+		return true
+	}
+}
+
+func isDeadlock(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "deadlock")
+}
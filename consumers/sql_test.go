@@ -0,0 +1,186 @@
+package consumers_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Fake driver storing committed rows, failing inserts of "bad" and
+// deadlocking the first inserts of "deadlock"
+type tableDriver struct {
+	mutex     sync.Mutex
+	rows      [][]driver.Value
+	commits   int
+	deadlocks int
+}
+
+type tableConn struct {
+	driver  *tableDriver
+	pending [][]driver.Value
+}
+
+type tableStmt struct{ conn *tableConn }
+
+func (d *tableDriver) Open(name string) (driver.Conn, error) { return &tableConn{driver: d}, nil }
+func (conn *tableConn) Prepare(query string) (driver.Stmt, error) {
+	return tableStmt{conn}, nil
+}
+func (conn *tableConn) Close() error              { return nil }
+func (conn *tableConn) Begin() (driver.Tx, error) { return conn, nil }
+func (stmt tableStmt) Close() error               { return nil }
+func (stmt tableStmt) NumInput() int              { return -1 }
+func (stmt tableStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func (stmt tableStmt) Exec(args []driver.Value) (driver.Result, error) {
+	d := stmt.conn.driver
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	switch args[0] {
+	case "bad":
+		return nil, errors.New("constraint violation")
+	case "deadlock":
+		if d.deadlocks > 0 {
+			d.deadlocks--
+			return nil, errors.New("Deadlock found when trying to get lock")
+		}
+	}
+
+	stmt.conn.pending = append(stmt.conn.pending, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (conn *tableConn) Commit() error {
+	conn.driver.mutex.Lock()
+	defer conn.driver.mutex.Unlock()
+	conn.driver.rows = append(conn.driver.rows, conn.pending...)
+	conn.driver.commits++
+	conn.pending = nil
+	return nil
+}
+
+func (conn *tableConn) Rollback() error {
+	conn.pending = nil
+	return nil
+}
+
+var table = &tableDriver{}
+
+func init() {
+	sql.Register("rivers-table", table)
+}
+
+func TestToSQL(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+		db, _ := sql.Open("rivers-table", "")
+		defer db.Close()
+		table.rows, table.commits, table.deadlocks = nil, 0, 0
+
+		Convey("And a stream of rows", func() {
+			in, out := stream.New(5)
+			out <- []interface{}{"a", 1}
+			out <- []interface{}{"b", 2}
+			out <- []interface{}{"c", 3}
+			out <- []interface{}{"d", 4}
+			out <- []interface{}{"e", 5}
+			close(out)
+
+			Convey("When I apply the sql consumer", func() {
+				consumer := consumers.ToSQL(db, "INSERT INTO t VALUES (?, ?)", consumers.SQLOptions{BatchSize: 2}, nil)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then rows are inserted in batches", func() {
+					So(context.Err(), ShouldBeNil)
+					So(len(table.rows), ShouldEqual, 5)
+					So(table.rows[4], ShouldResemble, []driver.Value{"e", int64(5)})
+					So(table.commits, ShouldEqual, 3)
+				})
+			})
+		})
+
+		Convey("And a stream of items deadlocking", func() {
+			in, out := stream.New(2)
+			out <- "a"
+			out <- "deadlock"
+			close(out)
+			table.deadlocks = 2
+
+			Convey("When I apply the sql consumer retrying deadlocks", func() {
+				consumer := consumers.ToSQL(db, "INSERT INTO t VALUES (?)", consumers.SQLOptions{
+					Retry: &stream.RetryPolicy{Attempts: 3, Backoff: time.Millisecond},
+				}, nil)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the batch is inserted once the deadlock is gone", func() {
+					So(context.Err(), ShouldBeNil)
+					So(table.rows, ShouldResemble, [][]driver.Value{{"a"}, {"deadlock"}})
+					So(table.commits, ShouldEqual, 1)
+				})
+			})
+
+			Convey("When I apply the sql consumer with the default retry policy", func() {
+				consumer := consumers.ToSQL(db, "INSERT INTO t VALUES (?)", consumers.SQLOptions{}, nil)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the batch is inserted once the deadlock is gone", func() {
+					So(context.Err(), ShouldBeNil)
+					So(table.rows, ShouldResemble, [][]driver.Value{{"a"}, {"deadlock"}})
+					So(table.commits, ShouldEqual, 1)
+				})
+			})
+
+			Convey("When I apply the sql consumer without retries", func() {
+				consumer := consumers.ToSQL(db, "INSERT INTO t VALUES (?)", consumers.SQLOptions{
+					Retry: &stream.RetryPolicy{Attempts: 1},
+				}, nil)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the failure", func() {
+					So(context.Err().Error(), ShouldContainSubstring, "Deadlock")
+					So(table.rows, ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("And a stream with an invalid item", func() {
+			in, out := stream.New(3)
+			out <- "a"
+			out <- "bad"
+			out <- "c"
+			close(out)
+
+			Convey("When I apply the sql consumer reporting failed batches", func() {
+				var failed []stream.T
+				consumer := consumers.ToSQL(db, "INSERT INTO t VALUES (?)", consumers.SQLOptions{
+					BatchSize: 2,
+					Retry:     &stream.RetryPolicy{Attempts: 3},
+				}, func(data stream.T, err error) {
+					failed = append(failed, data)
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the failed batch is reported without being retried", func() {
+					So(context.Err(), ShouldBeNil)
+					So(failed, ShouldResemble, []stream.T{[]stream.T{"a", "bad"}})
+					So(table.rows, ShouldResemble, [][]driver.Value{{"c"}})
+				})
+			})
+		})
+	})
+}
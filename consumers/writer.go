@@ -0,0 +1,100 @@
+package consumers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"io"
+)
+
+type flusher interface {
+	Flush() error
+}
+
+type writerSink struct {
+	context stream.Context
+	writer  io.Writer
+	encode  stream.EncodeFn
+}
+
+// Writes each item encoded by fn into w, flushing and closing w once the
+// stream is consumed in case it implements Flush() error and io.Closer.
+// Items are written as is if fn is nil, see Encode.
+func ToWriter(w io.Writer, fn stream.EncodeFn) stream.Consumer {
+	if fn == nil {
+		fn = Encode
+	}
+
+	return &writerSink{
+		writer: w,
+		encode: fn,
+	}
+}
+
+// Passes []byte and string items through, formatting
+// any other item with fmt.Sprint
+func Encode(data stream.T) ([]byte, error) {
+	switch data := data.(type) {
+	case []byte:
+		return data, nil
+	case string:
+		return []byte(data), nil
+	default:
+		return []byte(fmt.Sprint(data)), nil
+	}
+}
+
+// Same as Encode but terminates each item with a new line
+func EncodeLines(data stream.T) ([]byte, error) {
+	bytes, err := Encode(data)
+	return append(bytes, '\n'), err
+}
+
+// Same as ToWriter but writes each item as a JSON line
+func ToJSONLines(w io.Writer) stream.Consumer {
+	return ToWriter(w, EncodeJSONLines)
+}
+
+// Marshals each item as JSON terminated by a new line
+func EncodeJSONLines(data stream.T) ([]byte, error) {
+	bytes, err := json.Marshal(data)
+	return append(bytes, '\n'), err
+}
+
+func (sink *writerSink) Attach(context stream.Context) {
+	sink.context = context
+}
+
+func (sink *writerSink) Consume(in stream.Readable) {
+	defer sink.context.Recover()
+	defer sink.finish()
+
+	writer := &Sink{OnNext: sink.write}
+	writer.Attach(sink.context)
+	writer.Consume(in)
+}
+
+func (sink *writerSink) write(data stream.T) {
+	bytes, err := sink.encode(data)
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err := sink.writer.Write(bytes); err != nil {
+		panic(err)
+	}
+}
+
+func (sink *writerSink) finish() {
+	if flusher, ok := sink.writer.(flusher); ok {
+		if err := flusher.Flush(); err != nil {
+			panic(err)
+		}
+	}
+
+	if closer, ok := sink.writer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package consumers_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type closableBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (buffer *closableBuffer) Close() error {
+	buffer.closed = true
+	return nil
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk is full")
+}
+
+func TestToWriter(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- "a"
+			out <- []byte("b")
+			out <- 1
+			close(out)
+
+			Convey("When I apply the writer consumer with the default encoding", func() {
+				writer := &closableBuffer{}
+				consumer := consumers.ToWriter(writer, nil)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then items are written as is", func() {
+					So(writer.String(), ShouldEqual, "ab1")
+
+					Convey("And the writer is closed", func() {
+						So(writer.closed, ShouldBeTrue)
+					})
+				})
+			})
+
+			Convey("When I apply the writer consumer with a buffered writer", func() {
+				var buffer bytes.Buffer
+				consumer := consumers.ToWriter(bufio.NewWriter(&buffer), consumers.EncodeLines)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the writer is flushed once the stream is consumed", func() {
+					So(buffer.String(), ShouldEqual, "a\nb\n1\n")
+				})
+			})
+
+			Convey("When I apply the writer consumer with a custom encoding", func() {
+				var buffer bytes.Buffer
+				consumer := consumers.ToWriter(&buffer, func(data stream.T) ([]byte, error) {
+					if b, ok := data.([]byte); ok {
+						data = string(b)
+					}
+					return json.Marshal(data)
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then items are written using the given encoding", func() {
+					So(buffer.String(), ShouldEqual, `"a""b"1`)
+				})
+			})
+
+			Convey("When I apply the json lines consumer", func() {
+				var buffer bytes.Buffer
+				consumer := consumers.ToJSONLines(&buffer)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then items are written as json lines", func() {
+					So(buffer.String(), ShouldEqual, "\"a\"\n\"Yg==\"\n1\n")
+				})
+			})
+
+			Convey("When the writer fails", func() {
+				consumer := consumers.ToWriter(failingWriter{}, nil)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the failure", func() {
+					So(context.Err(), ShouldResemble, errors.New("disk is full"))
+				})
+			})
+		})
+	})
+}
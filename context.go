@@ -1,10 +1,12 @@
 package rivers
 
 import (
+	stdcontext "context"
 	"errors"
 	"fmt"
 	"github.com/drborges/rivers/stream"
 	"runtime/debug"
+	"sync"
 	"time"
 )
 
@@ -16,6 +18,12 @@ type context struct {
 	failure  chan struct{}
 	deadline time.Duration
 	err      error
+	mutex    sync.Mutex
+	handlers []func(error)
+	parent   stdcontext.Context
+	// Standard library view of the context, see ToStdContext
+	std     stdcontext.Context
+	stdOnce sync.Once
 }
 
 func NewContext() stream.Context {
@@ -27,6 +35,65 @@ func NewContext() stream.Context {
 	}
 }
 
+// Creates a context that is closed with ctx.Err() as soon as the given
+// standard library context is cancelled or its deadline is exceeded.
+func NewContextFrom(ctx stdcontext.Context) stream.Context {
+	context := NewContext().(*context)
+	context.parent = ctx
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			context.Close(ctx.Err())
+		case <-context.Failure():
+		case <-context.Done():
+		}
+	}()
+
+	return context
+}
+
+// Exposes the given context as a standard library context to be passed down
+// to client libraries. It is cancelled as soon as the rivers context is closed
+// having context.Cause reporting the rivers context error, if any. Values and
+// deadlines are inherited from the context given to NewContextFrom, if any.
+// The same standard library context is returned for a given rivers context.
+func ToStdContext(c stream.Context) stdcontext.Context {
+	if named, ok := c.(*namedContext); ok {
+		c = named.Context
+	}
+
+	context, ok := c.(*context)
+	if !ok {
+		return newStdContext(stdcontext.Background(), c)
+	}
+
+	context.stdOnce.Do(func() {
+		parent := context.parent
+		if parent == nil {
+			parent = stdcontext.Background()
+		}
+		context.std = newStdContext(parent, context)
+	})
+	return context.std
+}
+
+func newStdContext(parent stdcontext.Context, c stream.Context) stdcontext.Context {
+	ctx, cancel := stdcontext.WithCancelCause(parent)
+
+	go func() {
+		select {
+		case <-c.Failure():
+		case <-c.Done():
+		case <-ctx.Done():
+			return
+		}
+		cancel(c.Err())
+	}()
+
+	return ctx
+}
+
 func (context *context) Err() error {
 	return context.err
 }
@@ -47,6 +114,29 @@ func (context *context) Done() <-chan struct{} {
 	return context.success
 }
 
+// Registers a handler to be called when the context is closed due to a
+// failure. Handlers are called in registration order before the failure
+// is signaled downstream and must not close the context themselves.
+func (context *context) OnError(fn func(error)) {
+	context.mutex.Lock()
+	defer context.mutex.Unlock()
+	context.handlers = append(context.handlers, fn)
+}
+
+func (context *context) notify(err error) {
+	if err == nil || err == stream.Done {
+		return
+	}
+
+	context.mutex.Lock()
+	handlers := context.handlers
+	context.mutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(err)
+	}
+}
+
 func (context *context) Close(err error) {
 	context.requests <- err
 	ch := context.success
@@ -56,22 +146,52 @@ func (context *context) Close(err error) {
 
 	select {
 	case <-ch:
+		<-context.requests
 		return
 	default:
+		context.err = err
+		context.notify(err)
 		close(ch)
-		context.err = <-context.requests
+		<-context.requests
 	}
 }
 
 func (context *context) Recover() {
 	if r := recover(); r != nil {
-		if DebugEnabled && r != stream.Done {
-			debug.PrintStack()
+		if err := recovered(r); err != nil {
+			context.Close(err)
 		}
-		err := errors.New(fmt.Sprintf("Recovered from %v", r))
-		if e, ok := r.(error); ok {
-			err = e
+	}
+}
+
+// Context given to named stages reporting their failures as stream.StageError
+type namedContext struct {
+	stream.Context
+	name string
+}
+
+func (context *namedContext) Recover() {
+	if r := recover(); r != nil {
+		if err := recovered(r); err != nil {
+			context.Close(&stream.StageError{Stage: context.name, Err: err})
 		}
-		context.Close(err)
 	}
 }
+
+// Turns a recovered panic into the error failing the context, if any
+func recovered(r interface{}) error {
+	// Emitters panic with stream.Done once the context is
+	// already closed, which must not turn into a failure
+	if r == stream.Done {
+		return nil
+	}
+
+	if DebugEnabled {
+		debug.PrintStack()
+	}
+	err := errors.New(fmt.Sprintf("Recovered from %v", r))
+	if e, ok := r.(error); ok {
+		err = e
+	}
+	return err
+}
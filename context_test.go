@@ -0,0 +1,45 @@
+package rivers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	recoverFrom := func(context stream.Context, r interface{}) {
+		defer context.Recover()
+		panic(r)
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("When a stage recovers from a stream.Done panic", func() {
+			recoverFrom(context, stream.Done)
+
+			Convey("Then the context is not failed", func() {
+				failed := false
+				select {
+				case <-context.Failure():
+					failed = true
+				default:
+				}
+
+				So(failed, ShouldBeFalse)
+				So(context.Err(), ShouldBeNil)
+			})
+		})
+
+		Convey("When a stage recovers from a panic with an error", func() {
+			err := errors.New("boom")
+			recoverFrom(context, err)
+
+			Convey("Then the context is closed with the error", func() {
+				So(context.Err(), ShouldEqual, err)
+			})
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package dispatchers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+// Dispatches every item to all writables, each fed by its own bounded
+// queue so that a slow subscriber is handled by the given policy
+type broadcastDispatcher struct {
+	context   stream.Context
+	queueSize int
+	policy    Policy
+}
+
+func (dispatcher *broadcastDispatcher) Attach(context stream.Context) {
+	dispatcher.context = context
+}
+
+func (dispatcher *broadcastDispatcher) Dispatch(in stream.Readable, writables ...stream.Writable) stream.Readable {
+	notDispatchedReadable, notDispatchedWritable := stream.New(in.Capacity())
+
+	queueSize := dispatcher.queueSize
+	if queueSize <= 0 {
+		queueSize = in.Capacity()
+	}
+
+	forwarders := make([]*forwarder, len(writables))
+	for i, writable := range writables {
+		forwarders[i] = newForwarder(dispatcher.context, writable, queueSize, dispatcher.policy)
+	}
+
+	go func() {
+		defer dispatcher.context.Recover()
+		defer close(notDispatchedWritable)
+		defer func() {
+			for _, forwarder := range forwarders {
+				forwarder.Close()
+			}
+		}()
+
+		for {
+			select {
+			case <-dispatcher.context.Failure():
+				return
+			case <-time.After(dispatcher.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				// Gives priority to a failed context over pending data
+				select {
+				case <-dispatcher.context.Failure():
+					return
+				default:
+				}
+
+				for _, forwarder := range forwarders {
+					if !forwarder.Forward(data) {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return notDispatchedReadable
+}
@@ -0,0 +1,87 @@
+package dispatchers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/dispatchers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestBroadcastDispatcher(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			fastIn, fastOut := stream.New(5)
+			slowIn, slowOut := stream.New(0)
+
+			Convey("When I broadcast with the block policy", func() {
+				sink := dispatchers.New(context).Broadcast(2, dispatchers.Block).Dispatch(in, fastOut, slowOut)
+
+				Convey("Then every subscriber receives all items in order", func() {
+					slow := make(chan []stream.T)
+					go func() { slow <- slowIn.ReadAll() }()
+
+					So(fastIn.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+					So(<-slow, ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+					So(sink.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When I broadcast with the drop newest policy", func() {
+				sink := dispatchers.New(context).Broadcast(2, dispatchers.DropNewest).Dispatch(in, slowOut)
+				sink.ReadAll()
+
+				Convey("Then the slow subscriber misses the most recent items", func() {
+					slow := slowIn.ReadAll()
+					So(len(slow), ShouldBeBetween, 1, 4)
+					So(slow, ShouldResemble, []stream.T{1, 2, 3}[:len(slow)])
+				})
+			})
+
+			Convey("When I broadcast with the drop oldest policy", func() {
+				sink := dispatchers.New(context).Broadcast(2, dispatchers.DropOldest).Dispatch(in, slowOut)
+				sink.ReadAll()
+
+				Convey("Then the slow subscriber misses older items", func() {
+					slow := slowIn.ReadAll()
+					So(len(slow), ShouldBeBetween, 1, 4)
+					So(slow[len(slow)-2:], ShouldResemble, []stream.T{4, 5})
+				})
+			})
+
+			Convey("When I broadcast with the close subscriber policy", func() {
+				sink := dispatchers.New(context).Broadcast(2, dispatchers.CloseSubscriber).Dispatch(in, slowOut)
+				sink.ReadAll()
+
+				Convey("Then the slow subscriber stream is closed after its queued items", func() {
+					slow := slowIn.ReadAll()
+					So(len(slow), ShouldBeBetween, 1, 4)
+					So(slow, ShouldResemble, []stream.T{1, 2, 3}[:len(slow)])
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I broadcast the stream", func() {
+					dispatchers.New(context).Broadcast(2, dispatchers.Block).Dispatch(in, fastOut, slowOut)
+
+					Convey("Then the subscribers streams are closed", func() {
+						So(slowIn.ReadAll(), ShouldBeEmpty)
+						So(len(fastIn.ReadAll()), ShouldBeLessThanOrEqualTo, 5)
+					})
+				})
+			})
+		})
+	})
+}
@@ -3,23 +3,49 @@ package dispatchers
 import "github.com/drborges/rivers/stream"
 
 type Builder struct {
-	context stream.Context
+	context   stream.Context
+	queueSize int
 }
 
 func New(c stream.Context) *Builder {
-	return &Builder{c}
+	return &Builder{context: c}
+}
+
+// Ordered bounds the queue feeding each writable to the given size, which
+// defaults to the input stream capacity. Data is always delivered to each
+// writable in the source order.
+func (b *Builder) Ordered(queueSize int) *Builder {
+	b.queueSize = queueSize
+	return b
 }
 
 func (b *Builder) If(fn stream.PredicateFn) stream.Dispatcher {
 	return &dispatcher{
-		context: b.context,
-		fn:      fn,
+		context:   b.context,
+		fn:        fn,
+		queueSize: b.queueSize,
 	}
 }
 
 func (b *Builder) Always() stream.Dispatcher {
 	return &dispatcher{
+		context:   b.context,
+		fn:        func(_ stream.T) bool { return true },
+		queueSize: b.queueSize,
+	}
+}
+
+func (b *Builder) PartitionBy(fn stream.MapFn) stream.Dispatcher {
+	return &partitionDispatcher{
 		context: b.context,
-		fn:      func(_ stream.T) bool { return true },
+		fn:      fn,
+	}
+}
+
+func (b *Builder) Broadcast(queueSize int, policy Policy) stream.Dispatcher {
+	return &broadcastDispatcher{
+		context:   b.context,
+		queueSize: queueSize,
+		policy:    policy,
 	}
 }
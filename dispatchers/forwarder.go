@@ -0,0 +1,111 @@
+package dispatchers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+// Policy applied when a subscriber's queue is full
+type Policy int
+
+const (
+	// Blocks the dispatch until the subscriber catches up
+	Block Policy = iota
+	// Drops the oldest queued item making room for the new one
+	DropOldest
+	// Drops the new item keeping the queue untouched
+	DropNewest
+	// Stops dispatching to the subscriber closing its stream once
+	// the already queued items are delivered
+	CloseSubscriber
+)
+
+// Forwards data to a writable through a bounded queue drained by a
+// single goroutine, preserving the order in which data is queued
+type forwarder struct {
+	context stream.Context
+	queue   chan stream.T
+	policy  Policy
+	closed  bool
+}
+
+func newForwarder(context stream.Context, w stream.Writable, queueSize int, policy Policy) *forwarder {
+	forwarder := &forwarder{
+		context: context,
+		queue:   make(chan stream.T, queueSize),
+		policy:  policy,
+	}
+
+	go func() {
+		defer context.Recover()
+		defer close(w)
+
+		for data := range forwarder.queue {
+			select {
+			case <-context.Failure():
+				return
+			default:
+			}
+
+			select {
+			case <-context.Failure():
+				return
+			case <-time.After(context.Deadline()):
+				panic(stream.Timeout)
+			case w <- data:
+			}
+		}
+	}()
+
+	return forwarder
+}
+
+// Queues data according to the forwarder's policy, returning false
+// in case the context fails while waiting for room in the queue
+func (forwarder *forwarder) Forward(data stream.T) bool {
+	if forwarder.closed {
+		return true
+	}
+
+	select {
+	case forwarder.queue <- data:
+		return true
+	default:
+	}
+
+	switch forwarder.policy {
+	case DropNewest:
+	case CloseSubscriber:
+		forwarder.Close()
+	case DropOldest:
+		for {
+			select {
+			case forwarder.queue <- data:
+				return true
+			default:
+			}
+
+			select {
+			case <-forwarder.queue:
+			default:
+			}
+		}
+	default:
+		select {
+		case <-forwarder.context.Failure():
+			return false
+		case <-time.After(forwarder.context.Deadline()):
+			panic(stream.Timeout)
+		case forwarder.queue <- data:
+		}
+	}
+
+	return true
+}
+
+func (forwarder *forwarder) Close() {
+	if !forwarder.closed {
+		forwarder.closed = true
+		close(forwarder.queue)
+	}
+}
@@ -6,44 +6,39 @@ import (
 )
 
 type dispatcher struct {
-	context stream.Context
-	fn      stream.PredicateFn
+	context   stream.Context
+	fn        stream.PredicateFn
+	queueSize int
 }
 
 func (dispatcher *dispatcher) Attach(context stream.Context) {
 	dispatcher.context = context
 }
 
+// Dispatches data matching the dispatcher's predicate to all writables,
+// each fed by a single forwarding goroutine through a bounded queue so
+// that slow receivers only block the dispatch once their queue is full
 func (dispatcher *dispatcher) Dispatch(in stream.Readable, writables ...stream.Writable) stream.Readable {
 	notDispatchedReadable, notDispatchedWritable := stream.New(in.Capacity())
 
-	dispatchedCount := 0
-	done := make(chan bool, len(writables))
-
-	closeWritables := func() {
-		defer func() {
-			for _, writable := range writables {
-				close(writable)
-			}
-		}()
+	queueSize := dispatcher.queueSize
+	if queueSize <= 0 {
+		queueSize = in.Capacity()
+	}
 
-		expectedDoneMessages := dispatchedCount * len(writables)
-		for i := 0; i < expectedDoneMessages; i++ {
-			select {
-			case <-dispatcher.context.Failure():
-				return
-			case <-time.After(dispatcher.context.Deadline()):
-				panic(stream.Timeout)
-			case <-done:
-				continue
-			}
-		}
+	forwarders := make([]*forwarder, len(writables))
+	for i, writable := range writables {
+		forwarders[i] = newForwarder(dispatcher.context, writable, queueSize, Block)
 	}
 
 	go func() {
 		defer dispatcher.context.Recover()
 		defer close(notDispatchedWritable)
-		defer closeWritables()
+		defer func() {
+			for _, forwarder := range forwarders {
+				forwarder.Close()
+			}
+		}()
 
 		for data := range in {
 			select {
@@ -52,19 +47,19 @@ func (dispatcher *dispatcher) Dispatch(in stream.Readable, writables ...stream.W
 			case <-time.After(dispatcher.context.Deadline()):
 				panic(stream.Timeout)
 			default:
-				if dispatcher.fn(data) {
-					dispatchedCount++
-					for _, writable := range writables {
-						// dispatch data asynchronously so that
-						// slow receivers don't block the dispatch
-						// process
-						go func(w stream.Writable, d stream.T) {
-							w <- d
-							done <- true
-						}(writable, data)
+				if !dispatcher.fn(data) {
+					select {
+					case notDispatchedWritable <- data:
+					case <-dispatcher.context.Failure():
+						return
+					}
+					continue
+				}
+
+				for _, forwarder := range forwarders {
+					if !forwarder.Forward(data) {
+						return
 					}
-				} else {
-					notDispatchedWritable <- data
 				}
 			}
 		}
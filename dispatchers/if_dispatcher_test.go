@@ -1,12 +1,15 @@
 package dispatchers_test
 
 import (
+	"errors"
 	"github.com/drborges/rivers"
 	"github.com/drborges/rivers/dispatchers"
 	"github.com/drborges/rivers/stream"
 	. "github.com/smartystreets/goconvey/convey"
 	"testing"
 	"github.com/smartystreets/assertions/should"
+	"runtime"
+	"time"
 )
 
 func TestIfDispatcher(t *testing.T) {
@@ -59,6 +62,22 @@ func TestIfDispatcher(t *testing.T) {
 				})
 			})
 
+			Convey("When I dispatch to a consumer that is permanently blocked", func() {
+				goroutines := runtime.NumGoroutine()
+				_, blockedOut := stream.New(0)
+				sink := dispatchers.New(context).Always().Dispatch(in, blockedOut)
+				time.Sleep(20 * time.Millisecond)
+
+				Convey("Then no goroutine is spawned per dispatched item", func() {
+					So(runtime.NumGoroutine()-goroutines, ShouldBeLessThanOrEqualTo, 2)
+
+					Convey("And closing the context tears the dispatcher down", func() {
+						context.Close(errors.New("consumer is stuck"))
+						So(sink.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
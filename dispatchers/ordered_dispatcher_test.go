@@ -0,0 +1,76 @@
+package dispatchers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/dispatchers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestOrderedDispatcher(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(100)
+			expected := []stream.T{}
+			for i := 0; i < 100; i++ {
+				out <- i
+				expected = append(expected, i)
+			}
+			close(out)
+
+			Convey("When I apply an ordered always dispatcher", func() {
+				streamIn1, streamOut1 := stream.New(0)
+				streamIn2, streamOut2 := stream.New(0)
+				sink := dispatchers.New(context).Ordered(2).Always().Dispatch(in, streamOut1, streamOut2)
+
+				Convey("Then items are delivered to every stream in the source order", func() {
+					items2 := make(chan []stream.T)
+					go func() { items2 <- streamIn2.ReadAll() }()
+
+					So(streamIn1.ReadAll(), ShouldResemble, expected)
+					So(<-items2, ShouldResemble, expected)
+
+					Convey("And no item is dispatched to the sink stream", func() {
+						So(sink.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+
+			Convey("When I dispatch to a consumer that is permanently blocked", func() {
+				goroutines := runtime.NumGoroutine()
+				_, blockedOut := stream.New(0)
+				sink := dispatchers.New(context).Ordered(2).Always().Dispatch(in, blockedOut)
+				time.Sleep(50 * time.Millisecond)
+
+				Convey("Then the number of goroutines stays bounded", func() {
+					So(runtime.NumGoroutine()-goroutines, ShouldBeLessThanOrEqualTo, 2)
+
+					Convey("And closing the context tears the dispatcher down", func() {
+						context.Close(errors.New("consumer is stuck"))
+						So(sink.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the ordered dispatcher to the stream", func() {
+					evensIn, evensOut := stream.New(3)
+					sink := dispatchers.New(context).Ordered(2).Always().Dispatch(in, evensOut)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(evensIn.ReadAll(), ShouldBeEmpty)
+						So(sink.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package dispatchers
+
+import (
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"hash/fnv"
+	"time"
+)
+
+// Routes each item to a single writable picked by hashing the item's key,
+// so all items sharing a key land on the same writable in source order
+type partitionDispatcher struct {
+	context stream.Context
+	fn      stream.MapFn
+}
+
+func (dispatcher *partitionDispatcher) Attach(context stream.Context) {
+	dispatcher.context = context
+}
+
+func (dispatcher *partitionDispatcher) Dispatch(in stream.Readable, writables ...stream.Writable) stream.Readable {
+	notDispatchedReadable, notDispatchedWritable := stream.New(in.Capacity())
+
+	go func() {
+		defer dispatcher.context.Recover()
+		defer close(notDispatchedWritable)
+		defer func() {
+			for _, writable := range writables {
+				close(writable)
+			}
+		}()
+
+		for {
+			select {
+			case <-dispatcher.context.Failure():
+				return
+			case <-time.After(dispatcher.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				// Gives priority to a failed context over pending data
+				select {
+				case <-dispatcher.context.Failure():
+					return
+				default:
+				}
+
+				if len(writables) == 0 {
+					select {
+					case <-dispatcher.context.Failure():
+						return
+					case notDispatchedWritable <- data:
+					}
+					continue
+				}
+
+				writable := writables[partition(dispatcher.fn(data), len(writables))]
+				select {
+				case <-dispatcher.context.Failure():
+					return
+				case <-time.After(dispatcher.context.Deadline()):
+					panic(stream.Timeout)
+				case writable <- data:
+				}
+			}
+		}
+	}()
+
+	return notDispatchedReadable
+}
+
+func partition(key stream.T, n int) int {
+	hash := fnv.New32a()
+	fmt.Fprint(hash, key)
+	return int(hash.Sum32() % uint32(n))
+}
@@ -0,0 +1,68 @@
+package dispatchers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/dispatchers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestPartitionDispatcher(t *testing.T) {
+	byUser := func(data stream.T) stream.T { return data.([]stream.T)[0] }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(6)
+			out <- []stream.T{"bob", 1}
+			out <- []stream.T{"ann", 1}
+			out <- []stream.T{"bob", 2}
+			out <- []stream.T{"joe", 1}
+			out <- []stream.T{"ann", 2}
+			out <- []stream.T{"bob", 3}
+			close(out)
+
+			Convey("When I apply a partition dispatcher", func() {
+				streamIn1, streamOut1 := stream.New(6)
+				streamIn2, streamOut2 := stream.New(6)
+				sink := dispatchers.New(context).PartitionBy(byUser).Dispatch(in, streamOut1, streamOut2)
+
+				Convey("Then all items of a key land on the same stream in source order", func() {
+					So(sink.ReadAll(), ShouldBeEmpty)
+
+					partitions := make(map[stream.T]int)
+					counts := make(map[stream.T]int)
+					for i, readable := range []stream.Readable{streamIn1, streamIn2} {
+						for _, data := range readable.ReadAll() {
+							key := byUser(data)
+							if _, seen := partitions[key]; !seen {
+								partitions[key] = i
+							}
+							counts[key]++
+
+							So(partitions[key], ShouldEqual, i)
+							So(data.([]stream.T)[1], ShouldEqual, counts[key])
+						}
+					}
+
+					So(counts, ShouldResemble, map[stream.T]int{"bob": 3, "ann": 2, "joe": 1})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply a partition dispatcher", func() {
+					streamIn, streamOut := stream.New(6)
+					dispatchers.New(context).PartitionBy(byUser).Dispatch(in, streamOut)
+
+					Convey("Then no item is dispatched", func() {
+						So(streamIn.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
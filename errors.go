@@ -0,0 +1,88 @@
+package rivers
+
+import (
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"sync"
+)
+
+// Stream of per item errors reported by MapE, EachE and RetryBy stages. It
+// is closed once the pipeline is consumed, dropping any errors reported after.
+type errorStream struct {
+	context  stream.Context
+	readable stream.Readable
+	writable stream.Writable
+	mutex    sync.Mutex
+	closed   bool
+	stages   int
+	// Whether the stream was requested via Errors or DeadLetter
+	requested bool
+	// Dead letter consumers still processing the stream
+	consumers sync.WaitGroup
+}
+
+func newErrorStream(context stream.Context, capacity int) *errorStream {
+	readable, writable := stream.New(capacity)
+	return &errorStream{
+		context:  context,
+		readable: readable,
+		writable: writable,
+	}
+}
+
+// Returns a function reporting errors on behalf of a new stage
+func (errors *errorStream) reporter(name string) func(data stream.T, err error) {
+	errors.mutex.Lock()
+	errors.stages++
+	stage := fmt.Sprintf("%v#%v", name, errors.stages)
+	errors.mutex.Unlock()
+
+	return func(data stream.T, err error) {
+		errors.mutex.Lock()
+		defer errors.mutex.Unlock()
+
+		if errors.closed {
+			return
+		}
+
+		select {
+		case <-errors.context.Failure():
+		case errors.writable <- stream.ItemError{Data: data, Stage: stage, Err: err}:
+		}
+	}
+}
+
+func (errors *errorStream) consumeWith(consumer stream.Consumer) {
+	consumer.Attach(errors.context)
+	errors.consumers.Add(1)
+
+	go func() {
+		defer errors.consumers.Done()
+		consumer.Consume(errors.readable)
+	}()
+}
+
+func (errors *errorStream) request() {
+	errors.mutex.Lock()
+	defer errors.mutex.Unlock()
+	errors.requested = true
+}
+
+func (errors *errorStream) isRequested() bool {
+	errors.mutex.Lock()
+	defer errors.mutex.Unlock()
+	return errors.requested
+}
+
+// Closes the stream waiting for dead letter consumers to finish
+func (errors *errorStream) close() {
+	defer errors.consumers.Wait()
+
+	errors.mutex.Lock()
+	defer errors.mutex.Unlock()
+
+	if !errors.closed {
+		errors.closed = true
+		close(errors.writable)
+	}
+}
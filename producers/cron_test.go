@@ -0,0 +1,59 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers/producers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestCronSchedule(t *testing.T) {
+	at := func(value string) time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", value)
+		return t
+	}
+
+	Convey("Given I have a cron schedule on weekdays business hours", t, func() {
+		schedule, err := producers.ParseCron("*/15 9-17 * * 1-5")
+		So(err, ShouldBeNil)
+
+		Convey("Then the next time within business hours is on the next step", func() {
+			So(schedule.Next(at("2016-03-01 10:07")), ShouldResemble, at("2016-03-01 10:15"))
+			So(schedule.Next(at("2016-03-01 10:15")), ShouldResemble, at("2016-03-01 10:30"))
+		})
+
+		Convey("Then the next time after business hours is on the next morning", func() {
+			So(schedule.Next(at("2016-03-01 17:50")), ShouldResemble, at("2016-03-02 09:00"))
+		})
+
+		Convey("Then the next time on a friday evening is on monday", func() {
+			So(schedule.Next(at("2016-03-04 18:00")), ShouldResemble, at("2016-03-07 09:00"))
+		})
+	})
+
+	Convey("Given I have a cron schedule restricting both day of month and day of week", t, func() {
+		schedule, _ := producers.ParseCron("0 0 1,15 * 0")
+
+		Convey("Then either of them matches", func() {
+			So(schedule.Next(at("2016-03-01 00:00")), ShouldResemble, at("2016-03-06 00:00"))
+			So(schedule.Next(at("2016-03-13 00:00")), ShouldResemble, at("2016-03-15 00:00"))
+		})
+	})
+
+	Convey("Given I have a cron schedule on the 30th of february", t, func() {
+		schedule, _ := producers.ParseCron("0 0 30 2 *")
+
+		Convey("Then no next time is found", func() {
+			So(schedule.Next(at("2016-01-01 00:00")).IsZero(), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given I have invalid cron specs", t, func() {
+		Convey("Then they cannot be parsed", func() {
+			for _, spec := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+				_, err := producers.ParseCron(spec)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
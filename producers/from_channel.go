@@ -0,0 +1,113 @@
+package producers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"reflect"
+	"time"
+)
+
+type fromChannel struct {
+	context stream.Context
+	ch      <-chan stream.T
+}
+
+func FromChannel(ch <-chan stream.T) stream.Producer {
+	return &fromChannel{ch: ch}
+}
+
+func (producer *fromChannel) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromChannel) Produce() stream.Readable {
+	capacity := cap(producer.ch)
+	if capacity <= 0 {
+		capacity = 10
+	}
+	readable, writable := stream.New(capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		for {
+			select {
+			case <-producer.context.Failure():
+				return
+			case <-producer.context.Done():
+				return
+			case <-time.After(producer.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-producer.ch:
+				if !more {
+					return
+				}
+				emitter.Emit(data)
+			}
+		}
+	}()
+
+	return readable
+}
+
+type fromAnyChannel struct {
+	context stream.Context
+	ch      reflect.Value
+}
+
+// Same as FromChannel but accepts any receivable channel, e.g. chan int
+// or <-chan User, emitting its values until it is closed
+func FromAnyChannel(ch stream.T) stream.Producer {
+	cv := reflect.ValueOf(ch)
+
+	if cv.Kind() != reflect.Chan || cv.Type().ChanDir()&reflect.RecvDir == 0 {
+		panic("No such channel")
+	}
+
+	return &fromAnyChannel{ch: cv}
+}
+
+func (producer *fromAnyChannel) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromAnyChannel) Produce() stream.Readable {
+	capacity := producer.ch.Cap()
+	if capacity <= 0 {
+		capacity = 10
+	}
+	readable, writable := stream.New(capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(producer.context.Failure())},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(producer.context.Done())},
+			{Dir: reflect.SelectRecv},
+			{Dir: reflect.SelectRecv, Chan: producer.ch},
+		}
+
+		for {
+			cases[2].Chan = reflect.ValueOf(time.After(producer.context.Deadline()))
+
+			chosen, data, more := reflect.Select(cases)
+			switch chosen {
+			case 0, 1:
+				return
+			case 2:
+				panic(stream.Timeout)
+			}
+
+			if !more {
+				return
+			}
+			emitter.Emit(data.Interface())
+		}
+	}()
+
+	return readable
+}
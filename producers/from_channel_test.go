@@ -0,0 +1,95 @@
+package producers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestFromChannel(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a channel producer", func() {
+			ch := make(chan stream.T, 3)
+			ch <- 1
+			ch <- 2
+			ch <- 3
+			close(ch)
+
+			producer := producers.FromChannel(ch)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+				})
+			})
+		})
+
+		Convey("And I have a channel that is never closed", func() {
+			ch := make(chan stream.T)
+			producer := producers.FromChannel(ch)
+			producer.Attach(context)
+
+			Convey("When I close the context with an error", func() {
+				readable := producer.Produce()
+				context.Close(errors.New("pipeline torn down"))
+
+				Convey("Then the produced stream is closed", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
+
+func TestFromAnyChannel(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a typed channel producer", func() {
+			ch := make(chan int, 3)
+			ch <- 1
+			ch <- 2
+			ch <- 3
+			close(ch)
+
+			producer := producers.FromAnyChannel((<-chan int)(ch))
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+				})
+			})
+		})
+
+		Convey("And I have a typed channel that is never closed", func() {
+			ch := make(chan string)
+			producer := producers.FromAnyChannel(ch)
+			producer.Attach(context)
+
+			Convey("When I close the context", func() {
+				readable := producer.Produce()
+				context.Close(nil)
+
+				Convey("Then the produced stream is closed", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("Then I cannot produce data from send only channels or other values", func() {
+			So(func() { producers.FromAnyChannel(make(chan<- int)) }, ShouldPanic)
+			So(func() { producers.FromAnyChannel([]int{}) }, ShouldPanic)
+		})
+	})
+}
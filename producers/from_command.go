@@ -0,0 +1,93 @@
+package producers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"os/exec"
+	"strings"
+)
+
+type fromCommand struct {
+	context  stream.Context
+	cmd      *exec.Cmd
+	split    bufio.SplitFunc
+	Capacity int
+}
+
+// Starts the given command streaming its stdout split by the given split
+// function. The context is closed with an error holding the command stderr
+// in case it exits with a non zero status, unless cmd.Stderr is set. The
+// process is killed once the context is closed.
+func FromCommand(cmd *exec.Cmd, split bufio.SplitFunc) stream.Producer {
+	return &fromCommand{
+		cmd:      cmd,
+		split:    split,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromCommand) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromCommand) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		cmd := producer.cmd
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			panic(err)
+		}
+
+		var stderr bytes.Buffer
+		if cmd.Stderr == nil {
+			cmd.Stderr = &stderr
+		}
+
+		if err := cmd.Start(); err != nil {
+			panic(err)
+		}
+
+		finished := make(chan struct{})
+		defer close(finished)
+
+		go func() {
+			select {
+			case <-producer.context.Failure():
+			case <-producer.context.Done():
+			case <-finished:
+				return
+			}
+			cmd.Process.Kill()
+		}()
+
+		// Set in case reading stdout fails, in which case the process
+		// is killed and its exit status must not replace the error
+		var scanErr error
+
+		// Waits for the process even if emitting fails so it is not left behind
+		defer func() {
+			err := cmd.Wait()
+			if err != nil && scanErr == nil {
+				if msg := strings.TrimSpace(stderr.String()); msg != "" {
+					err = fmt.Errorf("%v: %v", err, msg)
+				}
+				panicUnlessClosed(producer.context, err)
+			}
+		}()
+
+		if scanErr = scan(stdout, producer.split, emitter); scanErr != nil {
+			cmd.Process.Kill()
+			panicUnlessClosed(producer.context, scanErr)
+		}
+	}()
+
+	return readable
+}
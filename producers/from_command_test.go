@@ -0,0 +1,79 @@
+package producers_test
+
+import (
+	"bufio"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"os/exec"
+	"testing"
+)
+
+func TestFromCommand(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("When I produce data from a command", func() {
+			producer := producers.FromCommand(exec.Command("sh", "-c", "echo Hello; echo there"), bufio.ScanLines)
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then the command output is produced", func() {
+				So(readable.ReadAll(), ShouldResemble, []stream.T{"Hello", "there"})
+				So(context.Err(), ShouldBeNil)
+			})
+		})
+
+		Convey("When I produce data from a failing command", func() {
+			producer := producers.FromCommand(exec.Command("sh", "-c", "echo partial; echo boom >&2; exit 3"), bufio.ScanLines)
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then the context is closed with the exit status and stderr", func() {
+				So(readable.ReadAll(), ShouldResemble, []stream.T{"partial"})
+				So(context.Err().Error(), ShouldEqual, "exit status 3: boom")
+			})
+		})
+
+		Convey("When I produce data from a command writing a line too long to be scanned", func() {
+			producer := producers.FromCommand(exec.Command("sh", "-c", "head -c 70000 /dev/zero | tr '\\0' a; echo; exec sleep 60"), bufio.ScanLines)
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then the context is closed with the scan error", func() {
+				So(readable.ReadAll(), ShouldBeEmpty)
+				So(context.Err(), ShouldEqual, bufio.ErrTooLong)
+			})
+		})
+
+		Convey("When I produce data from a command that does not exist", func() {
+			producer := producers.FromCommand(exec.Command("rivers-no-such-command"), bufio.ScanLines)
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then the context is closed with an error", func() {
+				So(readable.ReadAll(), ShouldBeEmpty)
+				So(context.Err(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When I produce data from a long running command", func() {
+			cmd := exec.Command("sh", "-c", "echo ready; exec sleep 60")
+			producer := producers.FromCommand(cmd, bufio.ScanLines)
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("And I close the context", func() {
+				So(<-readable, ShouldEqual, "ready")
+				context.Close(nil)
+
+				Convey("Then the process is killed and the stream closed without errors", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldBeNil)
+					So(cmd.ProcessState, ShouldNotBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package producers
+
+import (
+	"encoding/csv"
+	"github.com/drborges/rivers/stream"
+	"io"
+)
+
+type CSVOptions struct {
+	// Field delimiter, defaults to ','
+	Comma rune
+	// Lines starting with the comment character are ignored
+	Comment rune
+	// Emits records as map[string]string keyed by
+	// the first record of the input rather than []string
+	Header bool
+	// Allows quotes to appear in unquoted fields
+	LazyQuotes bool
+}
+
+type fromCSV struct {
+	context  stream.Context
+	reader   io.Reader
+	options  CSVOptions
+	Capacity int
+}
+
+// Streams each CSV record read from r as a []string, or as a map keyed by
+// the header fields in case options.Header is set. Readers implementing
+// io.Closer are closed once the stream is exhausted or the context is closed.
+func FromCSV(r io.Reader, options CSVOptions) stream.Producer {
+	return &fromCSV{
+		reader:   r,
+		options:  options,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromCSV) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromCSV) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+		defer closeOnDone(producer.context, producer.reader)()
+
+		reader := csv.NewReader(producer.reader)
+		reader.Comment = producer.options.Comment
+		reader.LazyQuotes = producer.options.LazyQuotes
+		if producer.options.Comma != 0 {
+			reader.Comma = producer.options.Comma
+		}
+
+		var header []string
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return
+			}
+
+			if err != nil {
+				panicUnlessClosed(producer.context, err)
+				return
+			}
+
+			if !producer.options.Header {
+				emitter.Emit(record)
+				continue
+			}
+
+			if header == nil {
+				header = record
+				continue
+			}
+
+			row := make(map[string]string, len(header))
+			for i, field := range record {
+				row[header[i]] = field
+			}
+			emitter.Emit(row)
+		}
+	}()
+
+	return readable
+}
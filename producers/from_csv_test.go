@@ -0,0 +1,64 @@
+package producers_test
+
+import (
+	"bytes"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestFromCSV(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a reader with csv data", func() {
+			reader := &closableReader{Reader: bytes.NewBufferString("name;bio\n# comment\nDiego;\"likes; quotes\"\n")}
+
+			Convey("When I produce records from the reader", func() {
+				producer := producers.FromCSV(reader, producers.CSVOptions{Comma: ';', Comment: '#'})
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then records are produced as string slices", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{
+						[]string{"name", "bio"},
+						[]string{"Diego", "likes; quotes"},
+					})
+
+					Convey("And the reader is closed", func() {
+						So(reader.closed, ShouldBeTrue)
+					})
+				})
+			})
+
+			Convey("When I produce records keyed by the header", func() {
+				producer := producers.FromCSV(reader, producers.CSVOptions{Comma: ';', Comment: '#', Header: true})
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then records are produced as maps", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{
+						map[string]string{"name": "Diego", "bio": "likes; quotes"},
+					})
+				})
+			})
+		})
+
+		Convey("And I have a reader with malformed csv data", func() {
+			reader := bytes.NewBufferString("a,b\nc\n")
+
+			Convey("When I produce records from the reader", func() {
+				producer := producers.FromCSV(reader, producers.CSVOptions{})
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with an error", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{[]string{"a", "b"}})
+					So(context.Err(), ShouldNotBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,101 @@
+package producers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+type fromDirTree struct {
+	context  stream.Context
+	root     string
+	glob     string
+	Capacity int
+}
+
+// Walks the directory tree under root concurrently emitting the path of each
+// file matching the given glob, see filepath.Match. Globs are matched against
+// the file name, or against the path relative to root in case they contain a
+// path separator. Paths are emitted in no particular order.
+func FromDirTree(root, glob string) stream.Producer {
+	return &fromDirTree{
+		root:     root,
+		glob:     glob,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromDirTree) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromDirTree) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		var walkers sync.WaitGroup
+		defer close(writable)
+		defer walkers.Wait()
+		defer producer.context.Recover()
+
+		if _, err := filepath.Match(producer.glob, ""); err != nil {
+			panic(err)
+		}
+
+		// Bounds the number of directories read at once
+		sem := make(chan struct{}, runtime.NumCPU())
+
+		var walk func(dir string)
+		walk = func(dir string) {
+			defer walkers.Done()
+			defer producer.context.Recover()
+
+			select {
+			case <-producer.context.Failure():
+				return
+			case <-producer.context.Done():
+				return
+			case sem <- struct{}{}:
+			}
+
+			entries, err := os.ReadDir(dir)
+			<-sem
+
+			if err != nil {
+				panic(err)
+			}
+
+			for _, entry := range entries {
+				path := filepath.Join(dir, entry.Name())
+				if entry.IsDir() {
+					walkers.Add(1)
+					go walk(path)
+					continue
+				}
+
+				if producer.matches(path) {
+					emitter.Emit(path)
+				}
+			}
+		}
+
+		walkers.Add(1)
+		walk(producer.root)
+	}()
+
+	return readable
+}
+
+func (producer *fromDirTree) matches(path string) bool {
+	name := filepath.Base(path)
+	if strings.ContainsRune(producer.glob, filepath.Separator) {
+		name, _ = filepath.Rel(producer.root, path)
+	}
+
+	matched, _ := filepath.Match(producer.glob, name)
+	return matched
+}
@@ -0,0 +1,101 @@
+package producers_test
+
+import (
+	"fmt"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestFromDirTree(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a directory tree", func() {
+			dir, _ := ioutil.TempDir("", "rivers")
+			defer os.RemoveAll(dir)
+
+			for _, path := range []string{"a.log", "b.txt", "app/c.log", "app/nested/d.log", "other/e.log"} {
+				os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755)
+				ioutil.WriteFile(filepath.Join(dir, path), nil, 0644)
+			}
+
+			readAll := func(glob string) []string {
+				producer := producers.FromDirTree(dir, glob)
+				producer.Attach(context)
+
+				var paths []string
+				for path := range producer.Produce() {
+					rel, _ := filepath.Rel(dir, path.(string))
+					paths = append(paths, rel)
+				}
+				sort.Strings(paths)
+				return paths
+			}
+
+			Convey("When I walk the tree matching file names", func() {
+				paths := readAll("*.log")
+
+				Convey("Then matching files from all directories are produced", func() {
+					So(paths, ShouldResemble, []string{"a.log", "app/c.log", "app/nested/d.log", "other/e.log"})
+				})
+			})
+
+			Convey("When I walk the tree matching relative paths", func() {
+				paths := readAll("app/*.log")
+
+				Convey("Then only files matching the relative path are produced", func() {
+					So(paths, ShouldResemble, []string{"app/c.log"})
+				})
+			})
+
+			Convey("When I walk the tree with an invalid glob", func() {
+				paths := readAll("[")
+
+				Convey("Then the context is closed with an error", func() {
+					So(paths, ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, filepath.ErrBadPattern)
+				})
+			})
+		})
+
+		Convey("When I walk a directory that does not exist", func() {
+			producer := producers.FromDirTree("/tmp/rivers_no_such_dir", "*")
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then the context is closed with an error", func() {
+				So(readable.ReadAll(), ShouldBeEmpty)
+				So(context.Err(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("And I have a large directory tree", func() {
+			dir, _ := ioutil.TempDir("", "rivers")
+			defer os.RemoveAll(dir)
+
+			for i := 0; i < 500; i++ {
+				path := filepath.Join(dir, string(rune('a'+i%26)), fmt.Sprint(i/26))
+				os.MkdirAll(path, 0755)
+				ioutil.WriteFile(filepath.Join(path, "file"), nil, 0644)
+			}
+
+			Convey("When I close the context while walking the tree", func() {
+				producer := producers.FromDirTree(dir, "*")
+				producer.Attach(context)
+				readable := producer.Produce()
+				<-readable
+				context.Close(nil)
+
+				Convey("Then the stream is closed before walking the whole tree", func() {
+					So(len(readable.ReadAll()), ShouldBeLessThan, 499)
+				})
+			})
+		})
+	})
+}
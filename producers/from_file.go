@@ -3,6 +3,7 @@ package producers
 import (
 	"bufio"
 	"github.com/drborges/rivers/stream"
+	"io"
 	"os"
 	"strings"
 )
@@ -24,6 +25,18 @@ func (builder *fromFile) ByLine() stream.Producer {
 	}
 }
 
+func (builder *fromFile) ByScanner(split bufio.SplitFunc) stream.Producer {
+	return &Observable{
+		Capacity: 100,
+		Emit: func(emitter stream.Emitter) {
+			defer builder.file.Close()
+			if err := scan(builder.file, split, emitter); err != nil {
+				panic(err)
+			}
+		},
+	}
+}
+
 func (builder *fromFile) ByDelimiter(delimiter byte) stream.Producer {
 	return &Observable{
 		Capacity: 100,
@@ -44,3 +57,12 @@ func (builder *fromFile) ByDelimiter(delimiter byte) stream.Producer {
 		},
 	}
 }
+
+func scan(r io.Reader, split bufio.SplitFunc, emitter stream.Emitter) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(split)
+	for scanner.Scan() {
+		emitter.Emit(scanner.Text())
+	}
+	return scanner.Err()
+}
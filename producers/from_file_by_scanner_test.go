@@ -0,0 +1,43 @@
+package producers_test
+
+import (
+	"bufio"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestFromFileByScanner(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a file with some data", func() {
+			ioutil.WriteFile("/tmp/from_file_by_scanner", []byte("Hello there\nfolks!"), 0644)
+			file, _ := os.Open("/tmp/from_file_by_scanner")
+
+			Convey("When I produce data from the file", func() {
+				producer := producers.FromFile(file).ByScanner(bufio.ScanWords)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"Hello", "there", "folks!"})
+				})
+			})
+
+			Convey("When I produce data from the file path", func() {
+				producer := producers.FromFileWithScanner("/tmp/from_file_by_scanner", bufio.ScanLines)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"Hello there", "folks!"})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestFromGenerator(t *testing.T) {
+	fibonacci := func(state stream.T) (stream.T, stream.T, bool) {
+		pair := state.([2]int)
+		return pair[0], [2]int{pair[1], pair[0] + pair[1]}, true
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a finite generator producer", func() {
+			producer := producers.FromGenerator(3, func(state stream.T) (stream.T, stream.T, bool) {
+				return state, state.(int) - 1, state.(int) > 0
+			})
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then items are generated until the generator is done", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{3, 2, 1})
+				})
+			})
+		})
+
+		Convey("And I have an infinite generator producer", func() {
+			producer := producers.FromGenerator([2]int{0, 1}, fibonacci)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then items are generated lazily", func() {
+					items := []stream.T{<-readable, <-readable, <-readable, <-readable, <-readable}
+					So(items, ShouldResemble, []stream.T{0, 1, 1, 2, 3})
+
+					Convey("And the stream is closed once I close the context", func() {
+						context.Close(nil)
+						readable.ReadAll()
+					})
+				})
+			})
+		})
+	})
+}
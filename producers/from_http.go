@@ -0,0 +1,166 @@
+package producers
+
+import (
+	"bufio"
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+var ErrBodyNotRewindable = errors.New("Request body cannot be sent again without GetBody")
+
+type HTTPOptions struct {
+	// Defaults to http.DefaultClient
+	Client *http.Client
+	// Performs the request again every interval once the previous
+	// response is consumed. Zero performs the request only once.
+	Interval time.Duration
+	// Retries failed requests, including responses with status
+	// 429 or 5xx. Nil fails the pipeline on the first failure.
+	Retry *RetryPolicy
+}
+
+type fromHTTP struct {
+	context stream.Context
+	request *http.Request
+	split   bufio.SplitFunc
+	options HTTPOptions
+	// Whether the request body has already been sent
+	sent     bool
+	Capacity int
+}
+
+// Performs the given request streaming the response body split by the
+// given split function. Responses with status other than 2xx fail the
+// pipeline, and pending requests are cancelled once the context is closed.
+func FromHTTP(req *http.Request, split bufio.SplitFunc, options HTTPOptions) stream.Producer {
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+
+	return &fromHTTP{
+		request:  req,
+		split:    split,
+		options:  options,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromHTTP) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromHTTP) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		ctx, cancel := cancelOnDone(producer.context, producer.request.Context())
+		defer cancel()
+
+		for {
+			if err := producer.fetch(ctx, emitter); err != nil {
+				panicUnlessClosed(producer.context, err)
+				return
+			}
+
+			if producer.options.Interval <= 0 || !wait(producer.context, producer.options.Interval) {
+				return
+			}
+		}
+	}()
+
+	return readable
+}
+
+// Performs the request retrying it according to the retry policy
+func (producer *fromHTTP) fetch(ctx stdcontext.Context, emitter stream.Emitter) error {
+	for failures := 1; ; failures++ {
+		req, err := producer.newRequest(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := producer.options.Client.Do(req)
+		if err == nil && resp.StatusCode/100 == 2 {
+			return producer.scan(resp.Body, emitter)
+		}
+
+		if err == nil {
+			err = fmt.Errorf("%v %v: %v", producer.request.Method, producer.request.URL, resp.Status)
+			retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5
+			discard(resp.Body)
+
+			if !retryable {
+				return err
+			}
+		}
+
+		policy := producer.options.Retry
+		if policy == nil || !policy.Allows(failures) {
+			return err
+		}
+
+		if !wait(producer.context, policy.Delay(failures)) {
+			return nil
+		}
+	}
+}
+
+// Clones the request rewinding its body, if any, so it can be sent again
+// on retries and polls. Requests with a body but no GetBody, see
+// http.NewRequest, can only be sent once.
+func (producer *fromHTTP) newRequest(ctx stdcontext.Context) (*http.Request, error) {
+	req := producer.request.Clone(ctx)
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		req.Body = body
+		return req, err
+	}
+
+	if producer.sent {
+		return nil, ErrBodyNotRewindable
+	}
+	producer.sent = true
+	return req, nil
+}
+
+func (producer *fromHTTP) scan(body io.ReadCloser, emitter stream.Emitter) error {
+	// Drains any remaining data so the connection can be reused
+	defer discard(body)
+	return scan(body, producer.split, emitter)
+}
+
+// Derives a context from parent which is cancelled once the given
+// rivers context is closed, or once the returned function is called
+func cancelOnDone(context stream.Context, parent stdcontext.Context) (stdcontext.Context, stdcontext.CancelFunc) {
+	ctx, cancel := stdcontext.WithCancel(parent)
+
+	go func() {
+		select {
+		case <-context.Failure():
+		case <-context.Done():
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	return ctx, cancel
+}
+
+func discard(body io.ReadCloser) {
+	io.Copy(ioutil.Discard, body)
+	body.Close()
+}
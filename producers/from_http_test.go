@@ -0,0 +1,122 @@
+package producers_test
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFromHTTP(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a server failing the first request", func() {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				fmt.Fprintf(w, "request %v\nfolks!", atomic.LoadInt32(&requests))
+			}))
+			defer server.Close()
+
+			req, _ := http.NewRequest("GET", server.URL, nil)
+
+			Convey("When I produce data retrying failed requests", func() {
+				producer := producers.FromHTTP(req, bufio.ScanLines, producers.HTTPOptions{
+					Retry: &producers.RetryPolicy{Attempts: 2, Backoff: time.Millisecond},
+				})
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the response body of the retried request is produced", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"request 2", "folks!"})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When I produce data without retrying failed requests", func() {
+				producer := producers.FromHTTP(req, bufio.ScanLines, producers.HTTPOptions{})
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the failure", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err().Error(), ShouldContainSubstring, "503 Service Unavailable")
+				})
+			})
+
+			Convey("When I poll the server on an interval", func() {
+				producer := producers.FromHTTP(req, bufio.ScanLines, producers.HTTPOptions{
+					Interval: time.Millisecond,
+					Retry:    &producers.RetryPolicy{Attempts: 2},
+				})
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then responses are produced until the context is closed", func() {
+					So(<-readable, ShouldEqual, "request 2")
+					So(<-readable, ShouldEqual, "folks!")
+					So(<-readable, ShouldEqual, "request 3")
+					context.Close(nil)
+
+					readable.ReadAll()
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("And I have a server echoing request bodies failing the first request", func() {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if atomic.AddInt32(&requests, 1) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				fmt.Fprintf(w, "%v %s", r.Method, body)
+			}))
+			defer server.Close()
+
+			req, _ := http.NewRequest("POST", server.URL, strings.NewReader("payload"))
+			options := producers.HTTPOptions{
+				Retry: &producers.RetryPolicy{Attempts: 2, Backoff: time.Millisecond},
+			}
+
+			Convey("When I produce data retrying failed requests", func() {
+				producer := producers.FromHTTP(req, bufio.ScanLines, options)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the request body is sent again on retries", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"POST payload"})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When I produce data from a request whose body cannot be rewound", func() {
+				req.GetBody = nil
+				producer := producers.FromHTTP(req, bufio.ScanLines, options)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the failure instead of retrying", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, producers.ErrBodyNotRewindable)
+					So(atomic.LoadInt32(&requests), ShouldEqual, 1)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package producers
+
+import (
+	"bufio"
+	"errors"
+	"github.com/drborges/rivers/stream"
+	"net"
+	"sync"
+)
+
+type fromListener struct {
+	context  stream.Context
+	listener net.Listener
+	split    bufio.SplitFunc
+	Capacity int
+}
+
+// Accepts connections from the given listener concurrently, merging the
+// data read from all of them split by the given split function into a
+// single stream. Connections failing to be read are closed without failing
+// the pipeline. The listener and any open connection are closed once the
+// context is closed, whereas closing the listener ends the stream as soon
+// as the connections already accepted are closed by their peers.
+func FromTCPListener(ln net.Listener, split bufio.SplitFunc) stream.Producer {
+	return &fromListener{
+		listener: ln,
+		split:    split,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromListener) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromListener) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		var conns sync.WaitGroup
+		defer close(writable)
+		defer conns.Wait()
+		defer producer.context.Recover()
+		defer closeOnDone(producer.context, producer.listener)()
+
+		for {
+			conn, err := producer.listener.Accept()
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			if err != nil {
+				panicUnlessClosed(producer.context, err)
+				return
+			}
+
+			conns.Add(1)
+			go func() {
+				defer conns.Done()
+				defer producer.context.Recover()
+				defer closeOnDone(producer.context, conn)()
+
+				// Read errors only affect the failing connection
+				scan(conn, producer.split, emitter)
+			}()
+		}
+	}()
+
+	return readable
+}
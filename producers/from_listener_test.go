@@ -0,0 +1,68 @@
+package producers_test
+
+import (
+	"bufio"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	. "github.com/smartystreets/goconvey/convey"
+	"net"
+	"sort"
+	"testing"
+)
+
+func TestFromTCPListener(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a listener", func() {
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+
+			Convey("When I produce data from the listener", func() {
+				producer := producers.FromTCPListener(listener, bufio.ScanLines)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("And many clients send data concurrently", func() {
+					first, _ := net.Dial("tcp", listener.Addr().String())
+					second, _ := net.Dial("tcp", listener.Addr().String())
+					first.Write([]byte("a\nb\n"))
+					second.Write([]byte("c\n"))
+
+					Convey("Then data from all clients is merged into the stream", func() {
+						items := []string{(<-readable).(string), (<-readable).(string), (<-readable).(string)}
+						sort.Strings(items)
+						So(items, ShouldResemble, []string{"a", "b", "c"})
+
+						Convey("And the stream is closed once the listener and clients are closed", func() {
+							listener.Close()
+							first.Close()
+							second.Close()
+
+							So(readable.ReadAll(), ShouldBeEmpty)
+							So(context.Err(), ShouldBeNil)
+						})
+					})
+				})
+
+				Convey("And a client is connected when I close the context", func() {
+					client, _ := net.Dial("tcp", listener.Addr().String())
+					defer client.Close()
+					client.Write([]byte("a\n"))
+					So(<-readable, ShouldEqual, "a")
+
+					context.Close(nil)
+
+					Convey("Then the stream is closed without errors", func() {
+						So(readable.ReadAll(), ShouldBeEmpty)
+						So(context.Err(), ShouldBeNil)
+
+						Convey("And the listener is closed", func() {
+							_, err := listener.Accept()
+							So(err, ShouldNotBeNil)
+						})
+					})
+				})
+			})
+		})
+	})
+}
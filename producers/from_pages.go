@@ -0,0 +1,77 @@
+package producers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"reflect"
+	"time"
+)
+
+// Returned by page fetchers to have the page fetched again after the given
+// duration, for instance once an API responds with 429 Too Many Requests
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (err RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited, retry after %v", err.RetryAfter)
+}
+
+type fromPages struct {
+	context  stream.Context
+	fetch    stream.PageFn
+	Capacity int
+}
+
+// Fetches pages with fn, starting with a nil cursor, emitting the items of
+// each page until fn returns a zero value next cursor. Pages failing with a
+// RateLimitError are fetched again, other errors fail the pipeline.
+func FromPages(fn stream.PageFn) stream.Producer {
+	return &fromPages{
+		fetch:    fn,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromPages) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromPages) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		var cursor stream.T
+		for {
+			items, next, err := producer.fetch(cursor)
+
+			var limited RateLimitError
+			if errors.As(err, &limited) {
+				if !wait(producer.context, limited.RetryAfter) {
+					return
+				}
+				continue
+			}
+
+			if err != nil {
+				panic(err)
+			}
+
+			for _, item := range items {
+				emitter.Emit(item)
+			}
+
+			if next == nil || reflect.ValueOf(next).IsZero() {
+				return
+			}
+			cursor = next
+		}
+	}()
+
+	return readable
+}
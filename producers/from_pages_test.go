@@ -0,0 +1,70 @@
+package producers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestFromPages(t *testing.T) {
+	pages := map[stream.T][]stream.T{
+		nil: {1, 2},
+		"b": {3},
+		"c": {4, 5},
+	}
+	cursors := map[stream.T]stream.T{nil: "b", "b": "c", "c": ""}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a paginated api limiting the request rate", func() {
+			var requests []stream.T
+			limited := false
+
+			producer := producers.FromPages(func(cursor stream.T) ([]stream.T, stream.T, error) {
+				requests = append(requests, cursor)
+				if cursor == "b" && !limited {
+					limited = true
+					return nil, nil, producers.RateLimitError{RetryAfter: time.Millisecond}
+				}
+				return pages[cursor], cursors[cursor], nil
+			})
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then items from all pages are produced", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+
+					Convey("And rate limited pages are fetched again", func() {
+						So(requests, ShouldResemble, []stream.T{nil, "b", "b", "c"})
+					})
+				})
+			})
+		})
+
+		Convey("And I have a paginated api failing on a page", func() {
+			producer := producers.FromPages(func(cursor stream.T) ([]stream.T, stream.T, error) {
+				if cursor == "b" {
+					return nil, nil, errors.New("server error")
+				}
+				return pages[cursor], cursors[cursor], nil
+			})
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the failure", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, 2})
+					So(context.Err(), ShouldResemble, errors.New("server error"))
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,98 @@
+package producers
+
+import (
+	"bufio"
+	"github.com/drborges/rivers/stream"
+	"io"
+	"time"
+)
+
+type fromReader struct {
+	context  stream.Context
+	reader   io.Reader
+	split    bufio.SplitFunc
+	Capacity int
+}
+
+// Streams the reader content split by the given split function. Readers
+// implementing io.Closer are closed once the stream is exhausted or as
+// soon as the context is closed, unblocking any pending read.
+func FromReaderWithScanner(r io.Reader, split bufio.SplitFunc) stream.Producer {
+	return &fromReader{
+		reader:   r,
+		split:    split,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromReader) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromReader) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		defer closeOnDone(producer.context, producer.reader)()
+
+		if err := scan(producer.reader, producer.split, emitter); err != nil {
+			panicUnlessClosed(producer.context, err)
+		}
+	}()
+
+	return readable
+}
+
+// Closes the resource as soon as the context is closed in case it implements
+// io.Closer. The returned function closes the resource right away and must
+// be called once the resource is no longer used.
+func closeOnDone(context stream.Context, resource interface{}) func() {
+	closer, ok := resource.(io.Closer)
+	if !ok {
+		return func() {}
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		select {
+		case <-context.Failure():
+			closer.Close()
+		case <-context.Done():
+			closer.Close()
+		case <-finished:
+		}
+	}()
+
+	return func() {
+		close(finished)
+		closer.Close()
+	}
+}
+
+// Blocks for the given duration returning false in case the
+// context is closed in the meantime
+func wait(context stream.Context, duration time.Duration) bool {
+	select {
+	case <-context.Failure():
+		return false
+	case <-context.Done():
+		return false
+	case <-time.After(duration):
+		return true
+	}
+}
+
+// Read errors caused by closing the reader
+// upon the context being closed are ignored
+func panicUnlessClosed(context stream.Context, err error) {
+	select {
+	case <-context.Failure():
+	case <-context.Done():
+	default:
+		panic(err)
+	}
+}
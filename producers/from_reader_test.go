@@ -0,0 +1,68 @@
+package producers_test
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"testing"
+)
+
+type closableReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closableReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestFromReaderWithScanner(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a closable reader with some data", func() {
+			reader := &closableReader{Reader: bytes.NewBufferString("Hello\nthere\nfolks!")}
+
+			Convey("When I produce data from the reader", func() {
+				producer := producers.FromReaderWithScanner(reader, bufio.ScanLines)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"Hello", "there", "folks!"})
+
+					Convey("And the reader is closed", func() {
+						So(reader.closed, ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Convey("And I have a reader that blocks", func() {
+			reader, writer := io.Pipe()
+			defer writer.Close()
+
+			Convey("When I produce data from the reader", func() {
+				producer := producers.FromReaderWithScanner(reader, bufio.ScanLines)
+				producer.Attach(context)
+				readable := producer.Produce()
+				writer.Write([]byte("Hello\n"))
+
+				Convey("And I close the context", func() {
+					So(<-readable, ShouldEqual, "Hello")
+					context.Close(nil)
+
+					Convey("Then the stream is closed without errors", func() {
+						So(readable.ReadAll(), ShouldBeEmpty)
+						So(context.Err(), ShouldBeNil)
+					})
+				})
+			})
+		})
+	})
+}
@@ -26,6 +26,39 @@ func TestFromSlice(t *testing.T) {
 			})
 		})
 
+		Convey("And I have a slice producer of structs given by pointer", func() {
+			type user struct{ Name string }
+			users := []user{{"Diego"}, {"Borges"}}
+			producer := producers.FromSlice(&users)
+
+			Convey("When I produce data", func() {
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{user{"Diego"}, user{"Borges"}})
+				})
+			})
+		})
+
+		Convey("And I have an array producer", func() {
+			producer := producers.FromSlice([2]string{"a", "b"})
+
+			Convey("When I produce data", func() {
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"a", "b"})
+				})
+			})
+		})
+
+		Convey("Then I cannot produce data from values other than slices", func() {
+			So(func() { producers.FromSlice(1) }, ShouldPanic)
+			So(func() { producers.FromSlice(&map[string]int{}) }, ShouldPanic)
+		})
+
 		Convey("And I have a data producer", func() {
 			producer := producers.FromData(1, 2, 3)
 
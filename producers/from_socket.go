@@ -0,0 +1,198 @@
+package producers
+
+import (
+	"bufio"
+	stdcontext "context"
+	"crypto/tls"
+	"errors"
+	"github.com/drborges/rivers/stream"
+	"net"
+	"time"
+)
+
+var (
+	ErrIdleTimeout       = errors.New("Connection has been idle for too long")
+	ErrConnectionDropped = errors.New("Connection has been dropped")
+)
+
+type RetryPolicy = stream.RetryPolicy
+
+type SocketOptions struct {
+	// Maximum time to wait for a connection to be established
+	Timeout time.Duration
+	// Interval between keep-alive probes, zero uses the system default
+	// and a negative value disables them
+	KeepAlive time.Duration
+	// Establishes a TLS connection with the given config if set
+	TLS *tls.Config
+	// Closes the context with ErrIdleTimeout in case no token is
+	// read from the connection within the idle duration
+	IdleTimeout time.Duration
+	// Reconnects with the given policy once the connection is
+	// dropped or fails to be established, if set. Drops count as
+	// failed attempts unless data was read from the connection.
+	Reconnect *RetryPolicy
+	// Called before reconnecting with the number of consecutive failed
+	// attempts so far and the error that caused the reconnection, if any
+	OnReconnect func(attempts int, err error)
+}
+
+type fromSocket struct {
+	context  stream.Context
+	network  string
+	address  string
+	split    bufio.SplitFunc
+	options  SocketOptions
+	Capacity int
+}
+
+func FromSocketWithScanner(network, address string, split bufio.SplitFunc) stream.Producer {
+	return FromSocketWithOptions(network, address, split, SocketOptions{})
+}
+
+// Closes the context with ErrIdleTimeout in case no token
+// is read from the connection within the idle duration
+func FromSocketWithScannerIdleTimeout(network, address string, split bufio.SplitFunc, idle time.Duration) stream.Producer {
+	return FromSocketWithOptions(network, address, split, SocketOptions{IdleTimeout: idle})
+}
+
+func FromSocketReconnecting(network, address string, split bufio.SplitFunc, policy RetryPolicy) stream.Producer {
+	return FromSocketWithOptions(network, address, split, SocketOptions{Reconnect: &policy})
+}
+
+func FromSocketTLS(network, address string, config *tls.Config, split bufio.SplitFunc) stream.Producer {
+	return FromSocketWithOptions(network, address, split, SocketOptions{TLS: config})
+}
+
+func FromSocketWithOptions(network, address string, split bufio.SplitFunc, options SocketOptions) stream.Producer {
+	return &fromSocket{
+		network:  network,
+		address:  address,
+		split:    split,
+		options:  options,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromSocket) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromSocket) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		// Aborts pending dials once the context is closed
+		ctx, cancel := cancelOnDone(producer.context, stdcontext.Background())
+		defer cancel()
+
+		attempts := 0
+		for {
+			conn, err := producer.dial(ctx)
+			if err != nil {
+				policy := producer.options.Reconnect
+				attempts++
+
+				if policy == nil || !policy.Allows(attempts) {
+					panicUnlessClosed(producer.context, err)
+					return
+				}
+
+				producer.reconnecting(attempts, err)
+				if !wait(producer.context, policy.Delay(attempts)) {
+					return
+				}
+				continue
+			}
+
+			read, err := producer.scan(conn, emitter)
+			policy := producer.options.Reconnect
+			if policy == nil {
+				return
+			}
+
+			if read {
+				attempts = 0
+			}
+			attempts++
+
+			if !policy.Allows(attempts) {
+				if err == nil {
+					err = ErrConnectionDropped
+				}
+				panicUnlessClosed(producer.context, err)
+				return
+			}
+
+			producer.reconnecting(attempts, err)
+			if !wait(producer.context, policy.Delay(attempts)) {
+				return
+			}
+		}
+	}()
+
+	return readable
+}
+
+func (producer *fromSocket) dial(ctx stdcontext.Context) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   producer.options.Timeout,
+		KeepAlive: producer.options.KeepAlive,
+	}
+
+	if producer.options.TLS != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: producer.options.TLS}
+		return tlsDialer.DialContext(ctx, producer.network, producer.address)
+	}
+
+	return dialer.DialContext(ctx, producer.network, producer.address)
+}
+
+func (producer *fromSocket) reconnecting(attempts int, err error) {
+	if producer.options.OnReconnect != nil {
+		producer.options.OnReconnect(attempts, err)
+	}
+}
+
+// Scans the connection until it is dropped returning whether any
+// data was read along with the read error, if any
+func (producer *fromSocket) scan(conn net.Conn, emitter stream.Emitter) (bool, error) {
+	finished := make(chan struct{})
+	defer close(finished)
+
+	// Unblocks any pending read once the context is closed
+	go func() {
+		select {
+		case <-producer.context.Failure():
+		case <-producer.context.Done():
+		case <-finished:
+		}
+		conn.Close()
+	}()
+
+	read := false
+	scanner := bufio.NewScanner(conn)
+	scanner.Split(producer.split)
+	for {
+		if producer.options.IdleTimeout > 0 {
+			conn.SetReadDeadline(time.Now().Add(producer.options.IdleTimeout))
+		}
+
+		if !scanner.Scan() {
+			break
+		}
+
+		read = true
+		emitter.Emit(scanner.Text())
+	}
+
+	if err, ok := scanner.Err().(net.Error); ok && err.Timeout() {
+		panic(ErrIdleTimeout)
+	}
+
+	return read, scanner.Err()
+}
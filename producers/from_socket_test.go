@@ -0,0 +1,296 @@
+package producers_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFromSocketWithScanner(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a server that sends some data", func() {
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+			defer listener.Close()
+
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				conn.Write([]byte("a\nb\n"))
+				conn.Close()
+			}()
+
+			producer := producers.FromSocketWithScanner("tcp", listener.Addr().String(), bufio.ScanLines)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"a", "b"})
+				})
+			})
+		})
+
+		Convey("And I have a server that goes silent", func() {
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+			defer listener.Close()
+
+			silent := make(chan struct{})
+			defer close(silent)
+
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				conn.Write([]byte("a\nb\n"))
+				<-silent
+			}()
+
+			producer := producers.FromSocketWithScannerIdleTimeout("tcp", listener.Addr().String(), bufio.ScanLines, 50*time.Millisecond)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then the pipeline terminates after the idle window", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"a", "b"})
+
+					<-context.Failure()
+					So(context.Err(), ShouldEqual, producers.ErrIdleTimeout)
+				})
+			})
+		})
+	})
+}
+
+func TestFromSocketReconnecting(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a server that drops the first connection", func() {
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+			defer listener.Close()
+
+			go func() {
+				first, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				first.Write([]byte("a\nb\n"))
+				first.Close()
+
+				second, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				second.Write([]byte("c\n"))
+			}()
+
+			policy := producers.RetryPolicy{Attempts: 3, Backoff: 10 * time.Millisecond}
+			producer := producers.FromSocketReconnecting("tcp", listener.Addr().String(), bufio.ScanLines, policy)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+				items := []stream.T{<-readable, <-readable, <-readable}
+				context.Close(nil)
+
+				Convey("Then data from both connections flows through", func() {
+					So(items, ShouldResemble, []stream.T{"a", "b", "c"})
+				})
+
+				Convey("And the stream is closed once the context is closed", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("And I have a server that closes every connection right away", func() {
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+			defer listener.Close()
+
+			accepts := make(chan time.Time, 10)
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					accepts <- time.Now()
+					conn.Close()
+				}
+			}()
+
+			policy := producers.RetryPolicy{Attempts: 3, Backoff: 20 * time.Millisecond}
+			producer := producers.FromSocketReconnecting("tcp", listener.Addr().String(), bufio.ScanLines, policy)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then it reconnects with backoff until the attempts are exhausted", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, producers.ErrConnectionDropped)
+					So(len(accepts), ShouldEqual, 3)
+
+					first, second, third := <-accepts, <-accepts, <-accepts
+					So(second.Sub(first), ShouldBeGreaterThanOrEqualTo, 20*time.Millisecond)
+					So(third.Sub(second), ShouldBeGreaterThanOrEqualTo, 40*time.Millisecond)
+				})
+			})
+		})
+
+		Convey("And I have no server to connect to", func() {
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+			address := listener.Addr().String()
+			listener.Close()
+
+			policy := producers.RetryPolicy{Attempts: 2, Backoff: time.Millisecond}
+			producer := producers.FromSocketReconnecting("tcp", address, bufio.ScanLines, policy)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the connection error after exhausting the attempts", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					<-context.Failure()
+					So(context.Err(), ShouldNotBeNil)
+				})
+			})
+		})
+	})
+}
+
+func TestFromSocketTLS(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a TLS server that sends some data", func() {
+			server := httptest.NewUnstartedServer(nil)
+			server.StartTLS()
+			server.Close()
+
+			listener, _ := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: server.TLS.Certificates})
+			defer listener.Close()
+
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				conn.Write([]byte("a\nb\n"))
+				conn.Close()
+			}()
+
+			Convey("When I produce data trusting the server certificate", func() {
+				config := server.Client().Transport.(*http.Transport).TLSClientConfig
+				producer := producers.FromSocketTLS("tcp", listener.Addr().String(), config, bufio.ScanLines)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"a", "b"})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When I produce data without trusting the server certificate", func() {
+				producer := producers.FromSocketTLS("tcp", listener.Addr().String(), &tls.Config{}, bufio.ScanLines)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the handshake error", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldNotBeNil)
+				})
+			})
+		})
+	})
+}
+
+func TestFromSocketWithOptions(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a server that drops the first connection", func() {
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+			defer listener.Close()
+
+			go func() {
+				first, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				first.Write([]byte("a\n"))
+				first.Close()
+
+				second, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				second.Write([]byte("b\n"))
+			}()
+
+			Convey("When I produce data reconnecting with a hook", func() {
+				reconnects := make(chan int, 10)
+				producer := producers.FromSocketWithOptions("tcp", listener.Addr().String(), bufio.ScanLines, producers.SocketOptions{
+					Reconnect: &producers.RetryPolicy{Attempts: 3, Backoff: time.Millisecond},
+					OnReconnect: func(attempts int, err error) {
+						reconnects <- attempts
+					},
+				})
+				producer.Attach(context)
+				readable := producer.Produce()
+				items := []stream.T{<-readable, <-readable}
+				context.Close(nil)
+
+				Convey("Then data from both connections flows through", func() {
+					So(items, ShouldResemble, []stream.T{"a", "b"})
+
+					Convey("And the hook is notified about the reconnection", func() {
+						So(<-reconnects, ShouldEqual, 1)
+					})
+				})
+			})
+		})
+
+		Convey("And I have a server that never accepts connections", func() {
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+			address := listener.Addr().String()
+			listener.Close()
+
+			Convey("When I produce data reconnecting with a dial timeout", func() {
+				producer := producers.FromSocketWithOptions("tcp", address, bufio.ScanLines, producers.SocketOptions{
+					Timeout:   10 * time.Millisecond,
+					KeepAlive: -1,
+					Reconnect: &producers.RetryPolicy{Backoff: time.Millisecond},
+				})
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the stream is closed once I close the context", func() {
+					time.Sleep(20 * time.Millisecond)
+					context.Close(nil)
+
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package producers
+
+import (
+	stdcontext "context"
+	"database/sql"
+	"github.com/drborges/rivers/stream"
+)
+
+// Scans the current row into a new item
+type RowScanFn func(rows *sql.Rows) (stream.T, error)
+
+type fromSQL struct {
+	context  stream.Context
+	db       *sql.DB
+	query    string
+	args     []interface{}
+	scan     RowScanFn
+	Capacity int
+}
+
+// Executes the given query streaming each row as a []interface{} holding
+// its column values. Rows are fetched as the stream is consumed and the
+// query is cancelled once the context is closed.
+func FromSQL(db *sql.DB, query string, args ...interface{}) stream.Producer {
+	return FromSQLScan(db, scanValues, query, args...)
+}
+
+// Same as FromSQL but rows are scanned by fn, for instance into structs
+func FromSQLScan(db *sql.DB, fn RowScanFn, query string, args ...interface{}) stream.Producer {
+	return &fromSQL{
+		db:       db,
+		query:    query,
+		args:     args,
+		scan:     fn,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromSQL) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromSQL) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		ctx, cancel := cancelOnDone(producer.context, stdcontext.Background())
+		defer cancel()
+
+		rows, err := producer.db.QueryContext(ctx, producer.query, producer.args...)
+		if err != nil {
+			panicUnlessClosed(producer.context, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			item, err := producer.scan(rows)
+			if err != nil {
+				panic(err)
+			}
+			emitter.Emit(item)
+		}
+
+		if err := rows.Err(); err != nil {
+			panicUnlessClosed(producer.context, err)
+		}
+	}()
+
+	return readable
+}
+
+func scanValues(rows *sql.Rows) (stream.T, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	return values, rows.Scan(pointers...)
+}
@@ -0,0 +1,126 @@
+package producers_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Fake driver answering queries like "users:3" with 3 rows of (id, name)
+type rowsDriver struct{}
+type rowsConn struct{}
+type rowsStmt struct{ query string }
+type fakeRows struct{ count, next int }
+
+func (rowsDriver) Open(name string) (driver.Conn, error)   { return rowsConn{}, nil }
+func (rowsConn) Prepare(query string) (driver.Stmt, error) { return rowsStmt{query}, nil }
+func (rowsConn) Close() error                              { return nil }
+func (rowsConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+func (rowsStmt) Close() error                              { return nil }
+func (rowsStmt) NumInput() int                             { return -1 }
+func (rowsStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (rows *fakeRows) Columns() []string { return []string{"id", "name"} }
+func (rows *fakeRows) Close() error      { return nil }
+
+func (stmt rowsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if !strings.HasPrefix(stmt.query, "users:") {
+		return nil, fmt.Errorf("invalid query %v", stmt.query)
+	}
+	count, _ := strconv.Atoi(strings.TrimPrefix(stmt.query, "users:"))
+	return &fakeRows{count: count}, nil
+}
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if rows.next == rows.count {
+		return io.EOF
+	}
+	rows.next++
+	dest[0] = int64(rows.next)
+	dest[1] = fmt.Sprintf("user-%v", rows.next)
+	return nil
+}
+
+func init() {
+	sql.Register("rivers-rows", rowsDriver{})
+}
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func TestFromSQL(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a database", func() {
+			db, _ := sql.Open("rivers-rows", "")
+			defer db.Close()
+
+			Convey("When I produce data from a query", func() {
+				producer := producers.FromSQL(db, "users:2")
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then each row is produced with its column values", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{
+						[]interface{}{int64(1), "user-1"},
+						[]interface{}{int64(2), "user-2"},
+					})
+				})
+			})
+
+			Convey("When I produce data from a query scanning rows into structs", func() {
+				producer := producers.FromSQLScan(db, func(rows *sql.Rows) (stream.T, error) {
+					var u user
+					return u, rows.Scan(&u.ID, &u.Name)
+				}, "users:2")
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then each row is produced as a struct", func() {
+					items := readable.ReadAll()
+					So(len(items), ShouldEqual, 2)
+					So(items[1], ShouldResemble, user{2, "user-2"})
+				})
+			})
+
+			Convey("When I produce data from a query consuming only part of the result", func() {
+				producer := producers.FromSQL(db, "users:1000")
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("And I close the context", func() {
+					<-readable
+					context.Close(nil)
+
+					Convey("Then the stream is closed before reading all rows", func() {
+						So(len(readable.ReadAll()), ShouldBeLessThan, 999)
+					})
+				})
+			})
+
+			Convey("When I produce data from an invalid query", func() {
+				producer := producers.FromSQL(db, "invalid")
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the query error", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldResemble, errors.New("invalid query invalid"))
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package producers
+
+import (
+	"bufio"
+	stdcontext "context"
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Server-Sent Event as described by the text/event-stream format
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+type fromSSE struct {
+	context     stream.Context
+	url         string
+	client      *http.Client
+	lastEventID string
+	retry       time.Duration
+	Capacity    int
+}
+
+// Streams each Event sent by the text/event-stream endpoint at the given url,
+// reconnecting with the Last-Event-ID header whenever the connection drops.
+// The reconnection delay defaults to 3s and may be changed by the server.
+// Responses with status 204 end the stream, other than 2xx fail the pipeline.
+func FromSSE(url string) stream.Producer {
+	return &fromSSE{
+		url:      url,
+		client:   http.DefaultClient,
+		retry:    3 * time.Second,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromSSE) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromSSE) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		ctx, cancel := cancelOnDone(producer.context, stdcontext.Background())
+		defer cancel()
+
+		for {
+			reconnect, err := producer.subscribe(ctx, emitter)
+			if err != nil {
+				panicUnlessClosed(producer.context, err)
+				return
+			}
+
+			if !reconnect || !wait(producer.context, producer.retry) {
+				return
+			}
+		}
+	}()
+
+	return readable
+}
+
+// Reads events from a new connection until it is dropped, returning
+// whether or not the client should reconnect
+func (producer *fromSSE) subscribe(ctx stdcontext.Context, emitter stream.Emitter) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", producer.url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if producer.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", producer.lastEventID)
+	}
+
+	resp, err := producer.client.Do(req)
+	if err != nil {
+		// Network errors are transient, the connection is retried
+		return true, nil
+	}
+	defer discard(resp.Body)
+
+	if resp.StatusCode == http.StatusNoContent {
+		return false, nil
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return false, fmt.Errorf("GET %v: %v", producer.url, resp.Status)
+	}
+
+	var event Event
+	var data []string
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if data != nil {
+				event.ID = producer.lastEventID
+				event.Data = strings.Join(data, "\n")
+				emitter.Emit(event)
+			}
+			event, data = Event{}, nil
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "id":
+			producer.lastEventID = value
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		case "retry":
+			if millis, err := strconv.Atoi(value); err == nil {
+				producer.retry = time.Duration(millis) * time.Millisecond
+			}
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,76 @@
+package producers_test
+
+import (
+	"fmt"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestFromSSE(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have an event stream dropping the connection", func() {
+			var mutex sync.Mutex
+			var lastEventIDs []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+				switch len(lastEventIDs) {
+				case 1:
+					fmt.Fprint(w, "retry: 10\nid: 1\nevent: greeting\ndata: hello\ndata: world\n\n: comment\nid: 2\ndata: bye\n\n")
+				case 2:
+					fmt.Fprint(w, "data: again\n\ndata: incomplete")
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer server.Close()
+
+			Convey("When I produce events from the stream", func() {
+				producer := producers.FromSSE(server.URL)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then events are produced across reconnections", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{
+						producers.Event{ID: "1", Event: "greeting", Data: "hello\nworld"},
+						producers.Event{ID: "2", Data: "bye"},
+						producers.Event{ID: "2", Data: "again"},
+					})
+
+					Convey("And reconnections resume from the last event id", func() {
+						So(lastEventIDs, ShouldResemble, []string{"", "2", "2"})
+					})
+				})
+			})
+		})
+
+		Convey("And I have an endpoint failing with an error status", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			}))
+			defer server.Close()
+
+			Convey("When I produce events from the endpoint", func() {
+				producer := producers.FromSSE(server.URL)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the failure", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err().Error(), ShouldContainSubstring, "403 Forbidden")
+				})
+			})
+		})
+	})
+}
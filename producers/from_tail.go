@@ -0,0 +1,136 @@
+package producers
+
+import (
+	"bufio"
+	"github.com/drborges/rivers/stream"
+	"io"
+	"os"
+	"time"
+)
+
+type fromTail struct {
+	context  stream.Context
+	path     string
+	split    bufio.SplitFunc
+	interval time.Duration
+	Capacity int
+}
+
+// Follows the file at the given path streaming the data appended to it split
+// by the given split function, similar to tail -f. The file is checked for
+// new data on the given interval and read from the start once it is truncated
+// or replaced by a new file, e.g. upon log rotation.
+func FromTail(path string, split bufio.SplitFunc, interval time.Duration) stream.Producer {
+	return &fromTail{
+		path:     path,
+		split:    split,
+		interval: interval,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromTail) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromTail) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		file, err := os.Open(producer.path)
+		if err != nil {
+			panic(err)
+		}
+		defer func() { file.Close() }()
+
+		offset, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			panic(err)
+		}
+
+		var pending []byte
+		chunk := make([]byte, 32*1024)
+
+		for {
+			n, err := file.Read(chunk)
+			if n > 0 {
+				offset += int64(n)
+				pending = producer.emit(append(pending, chunk[:n]...), false, emitter)
+				continue
+			}
+
+			if err != nil && err != io.EOF {
+				panic(err)
+			}
+
+			if !wait(producer.context, producer.interval) {
+				return
+			}
+
+			info, err := os.Stat(producer.path)
+			if os.IsNotExist(err) {
+				// Waits for the rotated file to be created
+				continue
+			}
+
+			if err != nil {
+				panic(err)
+			}
+
+			current, err := file.Stat()
+			if err != nil {
+				panic(err)
+			}
+
+			if !os.SameFile(current, info) {
+				// Flushes what is left from the rotated file
+				rest, err := io.ReadAll(file)
+				if err != nil {
+					panic(err)
+				}
+				producer.emit(append(pending, rest...), true, emitter)
+				pending = nil
+
+				file.Close()
+				if file, err = os.Open(producer.path); err != nil {
+					panic(err)
+				}
+				offset = 0
+				continue
+			}
+
+			if info.Size() < offset {
+				pending = nil
+				if offset, err = file.Seek(0, io.SeekStart); err != nil {
+					panic(err)
+				}
+			}
+		}
+	}()
+
+	return readable
+}
+
+// Emits the tokens found in data returning any remaining data
+func (producer *fromTail) emit(data []byte, atEOF bool, emitter stream.Emitter) []byte {
+	for len(data) > 0 {
+		advance, token, err := producer.split(data, atEOF)
+		if err != nil {
+			panic(err)
+		}
+
+		if token != nil {
+			emitter.Emit(string(token))
+		}
+
+		if advance == 0 {
+			break
+		}
+		data = data[advance:]
+	}
+	return data
+}
@@ -0,0 +1,79 @@
+package producers_test
+
+import (
+	"bufio"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func appendTo(path, data string) {
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	defer file.Close()
+	file.WriteString(data)
+}
+
+func TestFromTail(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a log file", func() {
+			dir, _ := ioutil.TempDir("", "rivers")
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "app.log")
+			appendTo(path, "old entry\n")
+
+			Convey("When I tail the file", func() {
+				producer := producers.FromTail(path, bufio.ScanLines, 5*time.Millisecond)
+				producer.Attach(context)
+				readable := producer.Produce()
+				time.Sleep(20 * time.Millisecond)
+
+				Convey("Then lines appended to the file are produced", func() {
+					appendTo(path, "first\nsec")
+					So(<-readable, ShouldEqual, "first")
+					appendTo(path, "ond\n")
+					So(<-readable, ShouldEqual, "second")
+
+					Convey("And lines are produced from the start once the file is truncated", func() {
+						ioutil.WriteFile(path, []byte("new\n"), 0644)
+						So(<-readable, ShouldEqual, "new")
+					})
+
+					Convey("And lines are produced from the new file once the file is rotated", func() {
+						appendTo(path, "last")
+						os.Rename(path, path+".1")
+						appendTo(path, "rotated\n")
+
+						So(<-readable, ShouldEqual, "last")
+						So(<-readable, ShouldEqual, "rotated")
+					})
+
+					Convey("And the stream is closed once I close the context", func() {
+						context.Close(nil)
+
+						So(readable.ReadAll(), ShouldBeEmpty)
+						So(context.Err(), ShouldBeNil)
+					})
+				})
+			})
+		})
+
+		Convey("When I tail a file that does not exist", func() {
+			producer := producers.FromTail("/tmp/rivers_no_such_file", bufio.ScanLines, time.Millisecond)
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then the context is closed with an error", func() {
+				So(readable.ReadAll(), ShouldBeEmpty)
+				So(context.Err(), ShouldNotBeNil)
+			})
+		})
+	})
+}
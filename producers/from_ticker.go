@@ -0,0 +1,87 @@
+package producers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type fromTicker struct {
+	context  stream.Context
+	next     func(now time.Time) (time.Time, error)
+	Capacity int
+}
+
+// Emits the current time.Time every d until the context is closed.
+// Ticks are dropped in case downstream stages fall behind.
+func FromTicker(d time.Duration) stream.Producer {
+	return &fromTicker{
+		next: func(now time.Time) (time.Time, error) {
+			return now.Add(d), nil
+		},
+		Capacity: 1,
+	}
+}
+
+// Emits the current time.Time on the given cron schedule until the context
+// is closed, e.g. "*/15 9-17 * * 1-5". Specs have 5 fields: minute, hour,
+// day of month, month and day of week. Invalid specs fail the pipeline.
+func FromCron(spec string) stream.Producer {
+	schedule, err := ParseCron(spec)
+
+	return &fromTicker{
+		next: func(now time.Time) (time.Time, error) {
+			if err != nil {
+				return time.Time{}, err
+			}
+			return schedule.Next(now), nil
+		},
+		Capacity: 1,
+	}
+}
+
+func (producer *fromTicker) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromTicker) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		for at := time.Now(); ; {
+			next, err := producer.next(at)
+			if err != nil {
+				panic(err)
+			}
+
+			if next.IsZero() {
+				return
+			}
+
+			var now time.Time
+			select {
+			case <-producer.context.Failure():
+				return
+			case <-producer.context.Done():
+				return
+			case now = <-time.After(time.Until(next)):
+			}
+
+			select {
+			case writable <- now:
+			default:
+			}
+
+			// Schedules the next tick from the previous one to avoid drifting
+			// unless it is already late, in which case missed ticks are skipped
+			at = next
+			if late, _ := producer.next(at); late.Before(now) {
+				at = now
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,48 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestFromTicker(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("When I produce ticks", func() {
+			start := time.Now()
+			producer := producers.FromTicker(10 * time.Millisecond)
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then timestamps are produced on the given interval", func() {
+				first := (<-readable).(time.Time)
+				second := (<-readable).(time.Time)
+
+				So(first.Sub(start), ShouldBeGreaterThanOrEqualTo, 10*time.Millisecond)
+				So(second.Sub(start), ShouldBeGreaterThanOrEqualTo, 20*time.Millisecond)
+
+				Convey("And the stream is closed once I close the context", func() {
+					context.Close(nil)
+
+					readable.ReadAll()
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("When I produce ticks from an invalid cron spec", func() {
+			producer := producers.FromCron("* * *")
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then the context is closed with an error", func() {
+				So(readable.ReadAll(), ShouldBeEmpty)
+				So(context.Err(), ShouldNotBeNil)
+			})
+		})
+	})
+}
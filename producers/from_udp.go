@@ -0,0 +1,81 @@
+package producers
+
+import (
+	"errors"
+	"github.com/drborges/rivers/stream"
+	"net"
+)
+
+// Datagram received from the given address
+type Datagram struct {
+	Data []byte
+	Addr net.Addr
+}
+
+type fromPacketConn struct {
+	context  stream.Context
+	listen   func() (net.PacketConn, error)
+	Capacity int
+}
+
+// Listens for UDP datagrams on the given address streaming each of
+// them as a Datagram until the context is closed
+func FromUDP(address string) stream.Producer {
+	return &fromPacketConn{
+		listen: func() (net.PacketConn, error) {
+			return net.ListenPacket("udp", address)
+		},
+		Capacity: 100,
+	}
+}
+
+// Same as FromUDP but reads datagrams from the given connection, which
+// is closed once the context is closed. Closing the connection ends
+// the stream.
+func FromPacketConn(conn net.PacketConn) stream.Producer {
+	return &fromPacketConn{
+		listen: func() (net.PacketConn, error) {
+			return conn, nil
+		},
+		Capacity: 100,
+	}
+}
+
+func (producer *fromPacketConn) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromPacketConn) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		conn, err := producer.listen()
+		if err != nil {
+			panic(err)
+		}
+		defer closeOnDone(producer.context, conn)()
+
+		buffer := make([]byte, 64*1024)
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			if err != nil {
+				panicUnlessClosed(producer.context, err)
+				return
+			}
+
+			data := make([]byte, n)
+			copy(data, buffer[:n])
+			emitter.Emit(Datagram{Data: data, Addr: addr})
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,65 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	. "github.com/smartystreets/goconvey/convey"
+	"net"
+	"testing"
+)
+
+func TestFromPacketConn(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a UDP connection", func() {
+			conn, _ := net.ListenPacket("udp", "127.0.0.1:0")
+
+			Convey("When I produce datagrams from the connection", func() {
+				producer := producers.FromPacketConn(conn)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("And a client sends some datagrams", func() {
+					client, _ := net.Dial("udp", conn.LocalAddr().String())
+					defer client.Close()
+					client.Write([]byte("counter:1|c"))
+					client.Write([]byte("gauge:2|g"))
+
+					Convey("Then each datagram is produced along with the sender address", func() {
+						first := (<-readable).(producers.Datagram)
+						second := (<-readable).(producers.Datagram)
+
+						So(string(first.Data), ShouldEqual, "counter:1|c")
+						So(string(second.Data), ShouldEqual, "gauge:2|g")
+						So(first.Addr.String(), ShouldEqual, client.LocalAddr().String())
+
+						Convey("And the stream is closed once I close the context", func() {
+							context.Close(nil)
+
+							So(readable.ReadAll(), ShouldBeEmpty)
+							So(context.Err(), ShouldBeNil)
+						})
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestFromUDP(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("When I produce datagrams from an invalid address", func() {
+			producer := producers.FromUDP("127.0.0.1:-1")
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then the context is closed with an error", func() {
+				So(readable.ReadAll(), ShouldBeEmpty)
+				So(context.Err(), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,141 @@
+package producers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Kind of change detected on a watched file
+type FileOp int
+
+const (
+	Create FileOp = iota
+	Modify
+	Delete
+)
+
+type FileEvent struct {
+	Path string
+	Op   FileOp
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+type fromWatch struct {
+	context  stream.Context
+	root     string
+	interval time.Duration
+	Capacity int
+}
+
+// Watches the files under the given directory emitting a FileEvent for each
+// file created, modified or deleted since the previous check. Changes are
+// detected by polling the directory tree on the given interval, comparing
+// modification times and sizes, and emitted sorted by path.
+func FromWatch(root string, interval time.Duration) stream.Producer {
+	return &fromWatch{
+		root:     root,
+		interval: interval,
+		Capacity: 100,
+	}
+}
+
+func (producer *fromWatch) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *fromWatch) Produce() stream.Readable {
+	readable, writable := stream.New(producer.Capacity)
+	emitter := stream.NewEmitter(producer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer producer.context.Recover()
+
+		previous, err := snapshot(producer.root)
+		if err != nil {
+			panic(err)
+		}
+
+		for {
+			select {
+			case <-producer.context.Failure():
+				return
+			case <-producer.context.Done():
+				return
+			case <-time.After(producer.interval):
+			}
+
+			current, err := snapshot(producer.root)
+			if err != nil {
+				panicUnlessClosed(producer.context, err)
+				return
+			}
+
+			for _, event := range diff(previous, current) {
+				emitter.Emit(event)
+			}
+			previous = current
+		}
+	}()
+
+	return readable
+}
+
+func snapshot(root string) (map[string]fileState, error) {
+	files := make(map[string]fileState)
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		// Files deleted while walking the tree are reported on the next check
+		if os.IsNotExist(err) && path != root {
+			return nil
+		}
+
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		files[path] = fileState{info.ModTime(), info.Size()}
+		return nil
+	})
+
+	return files, err
+}
+
+func diff(previous, current map[string]fileState) []FileEvent {
+	var events []FileEvent
+	for path, state := range current {
+		if old, ok := previous[path]; !ok {
+			events = append(events, FileEvent{path, Create})
+		} else if old != state {
+			events = append(events, FileEvent{path, Modify})
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			events = append(events, FileEvent{path, Delete})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Path < events[j].Path
+	})
+
+	return events
+}
@@ -0,0 +1,72 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFromWatch(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a directory with some files", func() {
+			dir, _ := ioutil.TempDir("", "rivers")
+			defer os.RemoveAll(dir)
+
+			os.Mkdir(filepath.Join(dir, "logs"), 0755)
+			ioutil.WriteFile(filepath.Join(dir, "a.log"), []byte("a"), 0644)
+			ioutil.WriteFile(filepath.Join(dir, "logs", "b.log"), []byte("b"), 0644)
+
+			Convey("When I watch the directory", func() {
+				producer := producers.FromWatch(dir, 10*time.Millisecond)
+				producer.Attach(context)
+				readable := producer.Produce()
+				time.Sleep(20 * time.Millisecond)
+
+				Convey("And I change its files", func() {
+					ioutil.WriteFile(filepath.Join(dir, "a.log"), []byte("appended"), 0644)
+					os.Remove(filepath.Join(dir, "logs", "b.log"))
+					ioutil.WriteFile(filepath.Join(dir, "logs", "c.log"), []byte("c"), 0644)
+
+					Convey("Then an event is produced for each change", func() {
+						events := map[string]producers.FileOp{}
+						for len(events) < 3 {
+							event := (<-readable).(producers.FileEvent)
+							events[event.Path] = event.Op
+						}
+
+						So(events, ShouldResemble, map[string]producers.FileOp{
+							filepath.Join(dir, "a.log"):         producers.Modify,
+							filepath.Join(dir, "logs", "b.log"): producers.Delete,
+							filepath.Join(dir, "logs", "c.log"): producers.Create,
+						})
+
+						Convey("And the stream is closed once I close the context", func() {
+							context.Close(nil)
+
+							So(readable.ReadAll(), ShouldBeEmpty)
+							So(context.Err(), ShouldBeNil)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("When I watch a directory that does not exist", func() {
+			producer := producers.FromWatch("/tmp/rivers_no_such_dir", time.Millisecond)
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("Then the context is closed with an error", func() {
+				So(readable.ReadAll(), ShouldBeEmpty)
+				So(context.Err(), ShouldNotBeNil)
+			})
+		})
+	})
+}
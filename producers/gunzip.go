@@ -0,0 +1,36 @@
+package producers
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+)
+
+type gunzipReader struct {
+	io.Reader
+	io.Closer
+}
+
+// Returns a reader transparently decompressing r in case its content
+// starts with the gzip magic bytes, reading r as is otherwise. Closing
+// the returned reader closes r in case it implements io.Closer.
+func Gunzip(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	var reader io.Reader = buffered
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		if reader, err = gzip.NewReader(buffered); err != nil {
+			return nil, err
+		}
+	}
+
+	if closer, ok := r.(io.Closer); ok {
+		return &gunzipReader{reader, closer}, nil
+	}
+
+	return reader, nil
+}
@@ -0,0 +1,75 @@
+package producers_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func gzipped(data string) []byte {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	writer.Write([]byte(data))
+	writer.Close()
+	return buffer.Bytes()
+}
+
+func TestGunzip(t *testing.T) {
+	Convey("Given I have a closable reader with gzipped data", t, func() {
+		source := &closableReader{Reader: bytes.NewBuffer(gzipped("Hello\nthere"))}
+
+		Convey("When I wrap it with gunzip", func() {
+			reader, err := producers.Gunzip(source)
+			So(err, ShouldBeNil)
+
+			Convey("Then the data is decompressed", func() {
+				data, _ := ioutil.ReadAll(reader)
+				So(string(data), ShouldEqual, "Hello\nthere")
+
+				Convey("And closing the reader closes the source", func() {
+					reader.(io.Closer).Close()
+					So(source.closed, ShouldBeTrue)
+				})
+			})
+		})
+	})
+
+	Convey("Given I have a reader with plain data", t, func() {
+		source := bytes.NewBufferString("plain")
+
+		Convey("When I wrap it with gunzip", func() {
+			reader, err := producers.Gunzip(source)
+			So(err, ShouldBeNil)
+
+			Convey("Then the data is read as is", func() {
+				data, _ := ioutil.ReadAll(reader)
+				So(string(data), ShouldEqual, "plain")
+			})
+		})
+	})
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a gzipped file", func() {
+			ioutil.WriteFile("/tmp/from_file_by_scanner.gz", gzipped("Hello there\nfolks!"), 0644)
+
+			Convey("When I produce data from the file path", func() {
+				producer := producers.FromFileWithScanner("/tmp/from_file_by_scanner.gz", bufio.ScanLines)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the decompressed data is produced", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"Hello there", "folks!"})
+				})
+			})
+		})
+	})
+}
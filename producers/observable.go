@@ -19,8 +19,10 @@ func (observable *Observable) Produce() stream.Readable {
 	readable, writable := stream.New(observable.Capacity)
 
 	go func() {
-		defer observable.context.Recover()
+		// Closes the stream only after recovering from any panic so consumers
+		// can see the pipeline error as soon as the stream is closed
 		defer close(writable)
+		defer observable.context.Recover()
 
 		if observable.Emit != nil {
 			observable.Emit(stream.NewEmitter(observable.context, writable))
@@ -19,14 +19,12 @@ func FromRange(from, to int) stream.Producer {
 	}
 }
 
+// Emits the elements of any slice or array, e.g. []int or []User,
+// given either by value or by pointer
 func FromSlice(slice stream.T) stream.Producer {
-	sv := reflect.ValueOf(slice)
+	sv := reflect.Indirect(reflect.ValueOf(slice))
 
-	if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Ptr {
-		panic("No such slice")
-	}
-
-	if sv.Kind() == reflect.Ptr && sv.Elem().Kind() != reflect.Slice {
+	if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
 		panic("No such slice")
 	}
 
@@ -40,6 +38,11 @@ func FromSlice(slice stream.T) stream.Producer {
 	}
 }
 
+// Same as FromSlice, named after FromAnyChannel for symmetry
+func FromAnySlice(slice stream.T) stream.Producer {
+	return FromSlice(slice)
+}
+
 func FromReader(r io.Reader) stream.Producer {
 	return &Observable{
 		Emit: func(emitter stream.Emitter) {
@@ -60,6 +63,24 @@ func FromReader(r io.Reader) stream.Producer {
 	}
 }
 
+// Lazily emits the items generated by fn starting from the given seed
+// state, until fn is no longer ok or the context is closed
+func FromGenerator(seed stream.T, fn stream.GeneratorFn) stream.Producer {
+	return &Observable{
+		Capacity: 100,
+		Emit: func(emitter stream.Emitter) {
+			for state := seed; ; {
+				item, next, ok := fn(state)
+				if !ok {
+					return
+				}
+				emitter.Emit(item)
+				state = next
+			}
+		},
+	}
+}
+
 func FromData(data ...stream.T) stream.Producer {
 	return FromSlice(data)
 }
@@ -67,3 +88,28 @@ func FromData(data ...stream.T) stream.Producer {
 func FromFile(f *os.File) *fromFile {
 	return &fromFile{f}
 }
+
+// Opens the file at the given path streaming its content split by
+// the given split function. Gzipped files are decompressed, see Gunzip.
+// IO errors are propagated to the context.
+func FromFileWithScanner(path string, split bufio.SplitFunc) stream.Producer {
+	return &Observable{
+		Capacity: 100,
+		Emit: func(emitter stream.Emitter) {
+			file, err := os.Open(path)
+			if err != nil {
+				panic(err)
+			}
+			defer file.Close()
+
+			reader, err := Gunzip(file)
+			if err != nil {
+				panic(err)
+			}
+
+			if err := scan(reader, split, emitter); err != nil {
+				panic(err)
+			}
+		},
+	}
+}
@@ -1,6 +1,12 @@
 package rivers
 
 import (
+	"bufio"
+	stdcontext "context"
+	"crypto/tls"
+	"database/sql"
+	"encoding/json"
+	"fmt"
 	"github.com/drborges/rivers/combiners"
 	"github.com/drborges/rivers/consumers"
 	"github.com/drborges/rivers/dispatchers"
@@ -8,6 +14,12 @@ import (
 	"github.com/drborges/rivers/stream"
 	"github.com/drborges/rivers/transformers"
 	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"reflect"
+	"runtime/pprof"
+	"strings"
 	"time"
 )
 
@@ -15,10 +27,25 @@ type Pipeline struct {
 	Context  stream.Context
 	Stream   stream.Readable
 	parallel bool
+	errors   *errorStream
+	// Name of the next stage, see Named
+	name string
+	// Whether the context is only used by this pipeline, see Then
+	owned bool
 }
 
 func From(producer stream.Producer) *Pipeline {
-	context := NewContext()
+	pipeline := FromWithContext(NewContext(), producer)
+	pipeline.owned = true
+	return pipeline
+}
+
+// Same as From, allowing pipelines to start off custom producers
+func ProducedBy(producer stream.Producer) *Pipeline {
+	return From(producer)
+}
+
+func FromWithContext(context stream.Context, producer stream.Producer) *Pipeline {
 	producer.Attach(context)
 
 	return &Pipeline{
@@ -35,6 +62,72 @@ func FromReader(r io.Reader) *Pipeline {
 	return From(producers.FromReader(r))
 }
 
+func FromReaderWithScanner(r io.Reader, split bufio.SplitFunc) *Pipeline {
+	return From(producers.FromReaderWithScanner(r, split))
+}
+
+func FromCSV(r io.Reader, options producers.CSVOptions) *Pipeline {
+	return From(producers.FromCSV(r, options))
+}
+
+// Streams each JSON line read from r decoded into a new value returned
+// by fn, which should be a pointer. Blank lines are skipped and decode
+// errors are reported on the pipeline's error stream if requested, see
+// Errors and DeadLetter, otherwise they fail the pipeline.
+func FromJSONLines(r io.Reader, fn stream.NewFn) *Pipeline {
+	pipeline := FromReaderWithScanner(r, bufio.ScanLines).Filter(func(data stream.T) bool {
+		return strings.TrimSpace(data.(string)) != ""
+	})
+
+	// Created upfront so that requesting it from the returned pipeline
+	// reaches the decoding stage
+	pipeline.errors = newErrorStream(pipeline.Context, pipeline.Stream.Capacity())
+	return pipeline.Apply(transformers.MapE(func(data stream.T) (stream.T, error) {
+		item := fn()
+		return item, json.Unmarshal([]byte(data.(string)), item)
+	}, pipeline.reporter("FromJSONLines", nil)))
+}
+
+func FromCommand(cmd *exec.Cmd, split bufio.SplitFunc) *Pipeline {
+	return From(producers.FromCommand(cmd, split))
+}
+
+func FromTicker(d time.Duration) *Pipeline {
+	return From(producers.FromTicker(d))
+}
+
+func FromCron(spec string) *Pipeline {
+	return From(producers.FromCron(spec))
+}
+
+func FromDirTree(root, glob string) *Pipeline {
+	return From(producers.FromDirTree(root, glob))
+}
+
+func FromSQL(db *sql.DB, query string, args ...interface{}) *Pipeline {
+	return From(producers.FromSQL(db, query, args...))
+}
+
+func FromSQLScan(db *sql.DB, fn producers.RowScanFn, query string, args ...interface{}) *Pipeline {
+	return From(producers.FromSQLScan(db, fn, query, args...))
+}
+
+func FromTail(path string, split bufio.SplitFunc, interval time.Duration) *Pipeline {
+	return From(producers.FromTail(path, split, interval))
+}
+
+func FromWatch(root string, interval time.Duration) *Pipeline {
+	return From(producers.FromWatch(root, interval))
+}
+
+func FromGenerator(seed stream.T, fn stream.GeneratorFn) *Pipeline {
+	return From(producers.FromGenerator(seed, fn))
+}
+
+func FromPages(fn stream.PageFn) *Pipeline {
+	return From(producers.FromPages(fn))
+}
+
 func FromData(data ...stream.T) *Pipeline {
 	return From(producers.FromData(data...))
 }
@@ -43,11 +136,128 @@ func FromSlice(slice stream.T) *Pipeline {
 	return From(producers.FromSlice(slice))
 }
 
+func FromAnySlice(slice stream.T) *Pipeline {
+	return From(producers.FromAnySlice(slice))
+}
+
+func FromFileWithScanner(path string, split bufio.SplitFunc) *Pipeline {
+	return From(producers.FromFileWithScanner(path, split))
+}
+
+func FromHTTP(req *http.Request, split bufio.SplitFunc, options producers.HTTPOptions) *Pipeline {
+	return From(producers.FromHTTP(req, split, options))
+}
+
+func FromSSE(url string) *Pipeline {
+	return From(producers.FromSSE(url))
+}
+
+func FromTCPListener(ln net.Listener, split bufio.SplitFunc) *Pipeline {
+	return From(producers.FromTCPListener(ln, split))
+}
+
+func FromSocketTLS(network, address string, config *tls.Config, split bufio.SplitFunc) *Pipeline {
+	return From(producers.FromSocketTLS(network, address, config, split))
+}
+
+func FromSocketWithOptions(network, address string, split bufio.SplitFunc, options producers.SocketOptions) *Pipeline {
+	return From(producers.FromSocketWithOptions(network, address, split, options))
+}
+
+func FromUDP(address string) *Pipeline {
+	return From(producers.FromUDP(address))
+}
+
+func FromPacketConn(conn net.PacketConn) *Pipeline {
+	return From(producers.FromPacketConn(conn))
+}
+
+func FromSocketWithScanner(network, address string, split bufio.SplitFunc) *Pipeline {
+	return From(producers.FromSocketWithScanner(network, address, split))
+}
+
+func FromSocketWithScannerIdleTimeout(network, address string, split bufio.SplitFunc, idle time.Duration) *Pipeline {
+	return From(producers.FromSocketWithScannerIdleTimeout(network, address, split, idle))
+}
+
+func FromSocketReconnecting(network, address string, split bufio.SplitFunc, policy stream.RetryPolicy) *Pipeline {
+	return From(producers.FromSocketReconnecting(network, address, split, policy))
+}
+
+func FromChannel(ch <-chan stream.T) *Pipeline {
+	return From(producers.FromChannel(ch))
+}
+
+func FromAnyChannel(ch stream.T) *Pipeline {
+	return From(producers.FromAnyChannel(ch))
+}
+
+// Creates a new pipeline stage out of the given stream
+// inheriting the current pipeline settings
+func (pipeline *Pipeline) derive(readable stream.Readable) *Pipeline {
+	return &Pipeline{
+		Context:  pipeline.Context,
+		Stream:   readable,
+		parallel: pipeline.parallel,
+		errors:   pipeline.errors,
+		owned:    pipeline.owned,
+	}
+}
+
+// Same as derive for pipelines sharing the context with other pipelines
+func (pipeline *Pipeline) branch(readable stream.Readable) *Pipeline {
+	branch := pipeline.derive(readable)
+	branch.owned = false
+	return branch
+}
+
+// Names the next stage for diagnostics. Its panics fail the pipeline with a
+// stream.StageError, its item errors are reported under the given name and
+// its goroutines are labeled with it in profiles, see runtime/pprof.
+func (pipeline *Pipeline) Named(name string) *Pipeline {
+	named := *pipeline
+	named.name = name
+	return &named
+}
+
+// Context attached to the next stage
+func (pipeline *Pipeline) stageContext() stream.Context {
+	if pipeline.name == "" {
+		return pipeline.Context
+	}
+	return &namedContext{pipeline.Context, pipeline.name}
+}
+
+// Name the next stage reports errors under, defaulting to its kind
+func (pipeline *Pipeline) stageName(kind string) string {
+	if pipeline.name == "" {
+		return kind
+	}
+	return pipeline.name
+}
+
+// Runs fn labeling the goroutines started by the next stage
+func (pipeline *Pipeline) labeled(fn func()) {
+	if pipeline.name == "" {
+		fn()
+		return
+	}
+	pprof.Do(stdcontext.Background(), pprof.Labels("stage", pipeline.name), func(stdcontext.Context) {
+		fn()
+	})
+}
+
 func (pipeline *Pipeline) Parallel() *Pipeline {
 	pipeline.parallel = true
 	return pipeline
 }
 
+// Changes the capacity of the pipeline stream which is then inherited by
+// the streams created by the following stages
+func (pipeline *Pipeline) WithBufferSize(size int) *Pipeline {
+	return pipeline.Apply(transformers.WithCapacity(size))
+}
+
 func (pipeline *Pipeline) Deadline(duration time.Duration) *Pipeline {
 	pipeline.Context.SetDeadline(duration)
 	return pipeline
@@ -59,80 +269,106 @@ func (pipeline *Pipeline) Split() (*Pipeline, *Pipeline) {
 }
 
 func (pipeline *Pipeline) SplitN(n int) []*Pipeline {
+	return pipeline.dispatchN(n, dispatchers.New(pipeline.Context).Always())
+}
+
+// Broadcast splits the pipeline into n pipelines each receiving every item
+// through a queue of the given size. The policy determines how slow
+// pipelines are handled once their queue is full.
+func (pipeline *Pipeline) Broadcast(n, queueSize int, policy dispatchers.Policy) []*Pipeline {
+	return pipeline.dispatchN(n, dispatchers.New(pipeline.Context).Broadcast(queueSize, policy))
+}
+
+// Tee splits the pipeline into n identical pipelines, same as Broadcast
+// blocking on slow pipelines, which must then be consumed concurrently
+func (pipeline *Pipeline) Tee(n int) []*Pipeline {
+	return pipeline.Broadcast(n, pipeline.Stream.Capacity(), dispatchers.Block)
+}
+
+// PartitionBy splits the pipeline into n pipelines routing all items sharing
+// the same key to the same pipeline. As with Partition all pipelines must be
+// consumed concurrently.
+func (pipeline *Pipeline) PartitionBy(fn stream.MapFn, n int) []*Pipeline {
+	return pipeline.dispatchN(n, dispatchers.New(pipeline.Context).PartitionBy(fn))
+}
+
+func (pipeline *Pipeline) dispatchN(n int, dispatcher stream.Dispatcher) []*Pipeline {
 	pipelines := make([]*Pipeline, n)
 	writables := make([]stream.Writable, n)
 	for i := 0; i < n; i++ {
 		readable, writable := stream.New(pipeline.Stream.Capacity())
 		writables[i] = writable
-		pipelines[i] = &Pipeline{
-			Context:  pipeline.Context,
-			Stream:   readable,
-			parallel: pipeline.parallel,
-		}
+		pipelines[i] = pipeline.branch(readable)
 	}
-	dispatchers.New(pipeline.Context).Always().Dispatch(pipeline.Stream, writables...)
+	dispatcher.Dispatch(pipeline.Stream, writables...)
 	return pipelines
 }
 
+// Partition splits the pipeline into two, one with the items matching the
+// given predicate and the other with the remaining items. Both pipelines
+// must be consumed, otherwise the partition process may block once the
+// pipelines buffers are full. Closing the context tears down both pipelines.
 func (pipeline *Pipeline) Partition(fn stream.PredicateFn) (*Pipeline, *Pipeline) {
 	lhsIn, lhsOut := stream.New(pipeline.Stream.Capacity())
 	rhsIn := dispatchers.New(pipeline.Context).If(fn).Dispatch(pipeline.Stream, lhsOut)
-	lhsPipeline := &Pipeline{Context: pipeline.Context, Stream: lhsIn, parallel: pipeline.parallel}
-	rhsPipeline := &Pipeline{Context: pipeline.Context, Stream: rhsIn, parallel: pipeline.parallel}
-	return lhsPipeline, rhsPipeline
+	return pipeline.branch(lhsIn), pipeline.branch(rhsIn)
 }
 
 func (pipeline *Pipeline) Dispatch(writables ...stream.Writable) *Pipeline {
-	return &Pipeline{
-		Context:  pipeline.Context,
-		Stream:   dispatchers.New(pipeline.Context).Always().Dispatch(pipeline.Stream, writables...),
-		parallel: pipeline.parallel,
-	}
+	return pipeline.branch(dispatchers.New(pipeline.Context).Always().Dispatch(pipeline.Stream, writables...))
 }
 
 func (pipeline *Pipeline) DispatchIf(fn stream.PredicateFn, writables ...stream.Writable) *Pipeline {
-	return &Pipeline{
-		Context:  pipeline.Context,
-		Stream:   dispatchers.New(pipeline.Context).If(fn).Dispatch(pipeline.Stream, writables...),
-		parallel: pipeline.parallel,
-	}
+	return pipeline.branch(dispatchers.New(pipeline.Context).If(fn).Dispatch(pipeline.Stream, writables...))
 }
 
 func (pipeline *Pipeline) Merge(pipelines ...*Pipeline) *Pipeline {
-	return pipeline.Combine(combiners.FIFO(), pipelines)
+	return pipeline.Combine(combiners.Merge(), pipelines)
+}
+
+func (pipeline *Pipeline) Concat(pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.Concat(), pipelines)
+}
+
+func (pipeline *Pipeline) Priority(pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.Priority(), pipelines)
+}
+
+func (pipeline *Pipeline) MergeSortedBy(fn stream.SortByFn, pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.MergeSortedBy(fn), pipelines)
 }
 
 func (pipeline *Pipeline) Zip(pipelines ...*Pipeline) *Pipeline {
 	return pipeline.Combine(combiners.Zip(), pipelines)
 }
 
+func (pipeline *Pipeline) ZipPad(fill stream.T, pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.ZipPad(fill), pipelines)
+}
+
 func (pipeline *Pipeline) ZipBy(fn stream.ReduceFn, pipelines ...*Pipeline) *Pipeline {
 	return pipeline.Combine(combiners.ZipBy(fn), pipelines)
 }
 
 func (pipeline *Pipeline) Combine(combiner stream.Combiner, pipelines []*Pipeline) *Pipeline {
-	combiner.Attach(pipeline.Context)
+	combiner.Attach(pipeline.stageContext())
 
 	readables := []stream.Readable{pipeline.Stream}
 	for _, p := range pipelines {
 		readables = append(readables, p.Stream)
 	}
 
-	return &Pipeline{
-		Context:  pipeline.Context,
-		Stream:   combiner.Combine(readables...),
-		parallel: pipeline.parallel,
-	}
+	var readable stream.Readable
+	pipeline.labeled(func() { readable = combiner.Combine(readables...) })
+	return pipeline.derive(readable)
 }
 
 func (pipeline *Pipeline) Apply(transformer stream.Transformer) *Pipeline {
-	transformer.Attach(pipeline.Context)
+	transformer.Attach(pipeline.stageContext())
 
-	return &Pipeline{
-		Stream:   transformer.Transform(pipeline.Stream),
-		Context:  pipeline.Context,
-		parallel: pipeline.parallel,
-	}
+	var readable stream.Readable
+	pipeline.labeled(func() { readable = transformer.Transform(pipeline.Stream) })
+	return pipeline.derive(readable)
 }
 
 func (pipeline *Pipeline) ApplyParallel(transformer stream.Transformer) *Pipeline {
@@ -153,14 +389,109 @@ func (pipeline *Pipeline) Filter(fn stream.PredicateFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.Filter(fn))
 }
 
+func (pipeline *Pipeline) ParallelFilter(workers int, fn stream.PredicateFn) *Pipeline {
+	return pipeline.Apply(transformers.ParallelFilter(workers, fn))
+}
+
 func (pipeline *Pipeline) OnData(fn stream.OnDataFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.OnData(fn))
 }
 
+func (pipeline *Pipeline) OnProgress(every int, fn stream.ProgressFn) *Pipeline {
+	return pipeline.Apply(transformers.OnProgress(every, fn))
+}
+
+func (pipeline *Pipeline) Enumerate() *Pipeline {
+	return pipeline.Apply(transformers.Enumerate())
+}
+
 func (pipeline *Pipeline) Map(fn stream.MapFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.Map(fn))
 }
 
+// Same as Map but fn may report a failure for a given item. Failures are
+// sent to the pipeline's error stream if one was requested via Errors,
+// otherwise they close the context failing the whole pipeline.
+func (pipeline *Pipeline) MapE(fn stream.MapEFn) *Pipeline {
+	report := pipeline.reporter("MapE", nil)
+	return pipeline.ApplyParallel(transformers.MapE(fn, report))
+}
+
+// Same as MapE but failed items are retried according to the given policy
+// before being reported
+func (pipeline *Pipeline) RetryBy(fn stream.MapEFn, policy stream.RetryPolicy) *Pipeline {
+	report := pipeline.reporter("RetryBy", nil)
+	return pipeline.ApplyParallel(transformers.RetryBy(fn, policy, report))
+}
+
+// Errors returns a stream of stream.ItemError reported by FromJSONLines, by
+// ToSQL and by the MapE, EachE and RetryBy stages applied from now on, which
+// then keep processing the following items. The stream is closed once the
+// pipeline is consumed and must be read concurrently otherwise the pipeline
+// blocks once it is full.
+func (pipeline *Pipeline) Errors() stream.Readable {
+	if pipeline.errors == nil {
+		pipeline.errors = newErrorStream(pipeline.Context, pipeline.Stream.Capacity())
+	}
+	pipeline.errors.request()
+	return pipeline.errors.readable
+}
+
+// Returns a function reporting errors of the next stage on the error stream
+// in case it is requested by the time they happen, otherwise falling back to
+// the given function. A nil fallback fails the pipeline with the error.
+func (pipeline *Pipeline) reporter(kind string, fallback func(data stream.T, err error)) func(data stream.T, err error) {
+	if pipeline.errors == nil {
+		return fallback
+	}
+
+	errors := pipeline.errors
+	report := errors.reporter(pipeline.stageName(kind))
+	return func(data stream.T, err error) {
+		switch {
+		case errors.isRequested():
+			report(data, err)
+		case fallback != nil:
+			fallback(data, err)
+		default:
+			panic(err)
+		}
+	}
+}
+
+// DeadLetter consumes the pipeline's error stream with the given consumer,
+// for instance to store failed items so they can be replayed later. The
+// pipeline is only considered consumed once the consumer is done.
+func (pipeline *Pipeline) DeadLetter(consumer stream.Consumer) *Pipeline {
+	pipeline.Errors()
+	pipeline.errors.consumeWith(consumer)
+	return pipeline
+}
+
+func (pipeline *Pipeline) MarshalProto() *Pipeline {
+	return pipeline.ApplyParallel(transformers.MarshalProto())
+}
+
+func (pipeline *Pipeline) UnmarshalProto(fn func() transformers.ProtoUnmarshaler) *Pipeline {
+	return pipeline.ApplyParallel(transformers.UnmarshalProto(fn))
+}
+
+func (pipeline *Pipeline) ParallelMap(workers int, fn stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.ParallelMap(workers, fn))
+}
+
+func (pipeline *Pipeline) ParallelMapOrdered(workers int, fn stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.ParallelMapOrdered(workers, fn))
+}
+
+func (pipeline *Pipeline) Catch(fn stream.MapFn, fallback stream.T) *Pipeline {
+	return pipeline.ApplyParallel(transformers.Catch(fn, fallback))
+}
+
+func (pipeline *Pipeline) CatchWith(fn stream.MapFn, recoverFn stream.RecoverFn) *Pipeline {
+	return pipeline.ApplyParallel(transformers.CatchWith(fn, recoverFn))
+}
+
 func (pipeline *Pipeline) FlatMap(fn stream.MapFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.Map(fn)).Flatten()
 }
@@ -169,6 +500,33 @@ func (pipeline *Pipeline) Each(fn stream.EachFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.Each(fn))
 }
 
+// Same as Each but fn may report a failure for a given item, which is then
+// handled according to the given strategy
+func (pipeline *Pipeline) EachE(fn stream.EachEFn, strategy stream.ErrorStrategy) *Pipeline {
+	var report func(data stream.T, err error)
+	if strategy == stream.Continue {
+		report = pipeline.reporter("EachE", func(data stream.T, err error) {})
+	}
+	return pipeline.ApplyParallel(transformers.EachE(fn, report))
+}
+
+// Calls fn once the stream ends, as long as the pipeline has not failed so
+// far, e.g. to release resources or report the pipeline is finished
+func (pipeline *Pipeline) OnComplete(fn func()) *Pipeline {
+	return pipeline.Finally(func(err error) {
+		if err == nil {
+			fn()
+		}
+	})
+}
+
+// Calls fn once the stream ends either successfully or not, see
+// transformers.Finally. Failures of later stages are not reported to fn,
+// which may only be called after the pipeline returns in case it fails.
+func (pipeline *Pipeline) Finally(fn func(err error)) *Pipeline {
+	return pipeline.Apply(transformers.Finally(fn))
+}
+
 func (pipeline *Pipeline) Find(subject stream.T) *Pipeline {
 	return pipeline.Apply(transformers.FindBy(func(data stream.T) bool {
 		return data == subject
@@ -183,52 +541,194 @@ func (pipeline *Pipeline) TakeFirst(n int) *Pipeline {
 	return pipeline.Apply(transformers.TakeFirst(n))
 }
 
+// Same as TakeFirst
+func (pipeline *Pipeline) TakeN(n int) *Pipeline {
+	return pipeline.Apply(transformers.Take(n))
+}
+
 func (pipeline *Pipeline) Take(fn stream.PredicateFn) *Pipeline {
 	return pipeline.Filter(fn)
 }
 
+func (pipeline *Pipeline) TakeWhile(fn stream.PredicateFn) *Pipeline {
+	return pipeline.Apply(transformers.TakeWhile(fn))
+}
+
 func (pipeline *Pipeline) DropFirst(n int) *Pipeline {
 	return pipeline.Apply(transformers.DropFirst(n))
 }
 
+func (pipeline *Pipeline) DropWhile(fn stream.PredicateFn) *Pipeline {
+	return pipeline.Apply(transformers.DropWhile(fn))
+}
+
 func (pipeline *Pipeline) Drop(fn stream.PredicateFn) *Pipeline {
 	return pipeline.Take(func(data stream.T) bool { return !fn(data) })
 }
 
+func (pipeline *Pipeline) Sample(n int) *Pipeline {
+	return pipeline.Apply(transformers.Sample(n))
+}
+
+func (pipeline *Pipeline) Distinct() *Pipeline {
+	return pipeline.Apply(transformers.Distinct())
+}
+
+func (pipeline *Pipeline) DistinctBy(fn stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.DistinctBy(fn))
+}
+
+func (pipeline *Pipeline) DistinctLRU(size int) *Pipeline {
+	return pipeline.Apply(transformers.DistinctLRU(size))
+}
+
+func (pipeline *Pipeline) DistinctByLRU(size int, fn stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.DistinctByLRU(size, fn))
+}
+
+func (pipeline *Pipeline) DistinctConsecutive() *Pipeline {
+	return pipeline.Apply(transformers.DistinctConsecutive())
+}
+
+func (pipeline *Pipeline) DistinctConsecutiveBy(fn stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.DistinctConsecutiveBy(fn))
+}
+
 func (pipeline *Pipeline) Reduce(acc stream.T, fn stream.ReduceFn) *Pipeline {
 	return pipeline.Apply(transformers.Reduce(acc, fn))
 }
 
+func (pipeline *Pipeline) ReduceBy(keyFn stream.MapFn, seed stream.T, fn stream.ReduceFn) *Pipeline {
+	return pipeline.Apply(transformers.ReduceBy(keyFn, seed, fn))
+}
+
+func (pipeline *Pipeline) Scan(acc stream.T, fn stream.ReduceFn) *Pipeline {
+	return pipeline.Apply(transformers.Scan(acc, fn))
+}
+
+func (pipeline *Pipeline) Reduce1(fn stream.ReduceFn) *Pipeline {
+	return pipeline.Apply(transformers.Reduce1(fn))
+}
+
+func (pipeline *Pipeline) TopN(n int, fn stream.SortByFn) *Pipeline {
+	return pipeline.Apply(transformers.TopN(n, fn))
+}
+
 func (pipeline *Pipeline) Flatten() *Pipeline {
 	return pipeline.ApplyParallel(transformers.Flatten())
 }
 
+func (pipeline *Pipeline) FlattenDeep() *Pipeline {
+	return pipeline.ApplyParallel(transformers.FlattenDeep())
+}
+
 func (pipeline *Pipeline) Batch(size int) *Pipeline {
 	return pipeline.Apply(transformers.Batch(size))
 }
 
+func (pipeline *Pipeline) BatchFlushOnClose(size int) *Pipeline {
+	return pipeline.Apply(transformers.BatchFlushOnClose(size))
+}
+
+func (pipeline *Pipeline) Window(size int) *Pipeline {
+	return pipeline.Apply(transformers.Window(size))
+}
+
+func (pipeline *Pipeline) WindowEvery(size, slide int) *Pipeline {
+	return pipeline.Apply(transformers.WindowEvery(size, slide))
+}
+
+func (pipeline *Pipeline) SlidingWindow(size, step int) *Pipeline {
+	return pipeline.Apply(transformers.SlidingWindow(size, step))
+}
+
+func (pipeline *Pipeline) SlidingWindowWithTail(size, step int) *Pipeline {
+	return pipeline.Apply(transformers.SlidingWindowWithTail(size, step))
+}
+
+func (pipeline *Pipeline) BatchBySizeOrTime(size int, duration time.Duration) *Pipeline {
+	return pipeline.Apply(transformers.BatchBySizeOrTime(size, duration))
+}
+
 func (pipeline *Pipeline) BatchBy(batch stream.Batch) *Pipeline {
 	return pipeline.Apply(transformers.BatchBy(batch))
 }
 
+func (pipeline *Pipeline) Throttle(n int, per time.Duration) *Pipeline {
+	return pipeline.Apply(transformers.Throttle(n, per))
+}
+
+func (pipeline *Pipeline) Buffer(size int, policy transformers.OverflowPolicy) *Pipeline {
+	return pipeline.Apply(transformers.Buffer(size, policy))
+}
+
+// Same as Then, allowing pipelines to end on custom consumers
+func (pipeline *Pipeline) ConsumedBy(consumer stream.Consumer) error {
+	return pipeline.Then(consumer)
+}
+
+// Consumes the pipeline with the given consumer. Pipelines created with From
+// have their context closed once consumed, stopping any stage still producing
+// data, e.g. upstream stages of TakeFirst. Contexts given to FromWithContext
+// or shared by split pipelines are left to be closed by the caller.
 func (pipeline *Pipeline) Then(consumer stream.Consumer) error {
-	consumer.Attach(pipeline.Context)
-	consumer.Consume(pipeline.Stream)
-	return pipeline.Context.Err()
+	consumer.Attach(pipeline.stageContext())
+	pipeline.labeled(func() { consumer.Consume(pipeline.Stream) })
+	if pipeline.errors != nil {
+		pipeline.errors.close()
+	}
+
+	err := pipeline.Context.Err()
+	if pipeline.owned && err == nil {
+		select {
+		case <-pipeline.Context.Done():
+		case <-pipeline.Context.Failure():
+		default:
+			pipeline.Context.Close(nil)
+		}
+	}
+	return err
+}
+
+// Push style consumer notified of each item and then either of
+// the pipeline failure or its completion
+type Observer interface {
+	OnNext(data stream.T)
+	OnError(err error)
+	OnComplete()
+}
+
+// Consumes the pipeline in the background pushing items to the observer,
+// which is then notified of the pipeline outcome exactly once
+func (pipeline *Pipeline) Subscribe(observer Observer) {
+	go func() {
+		if err := pipeline.Then(consumers.CollectBy(observer.OnNext)); err != nil {
+			observer.OnError(err)
+			return
+		}
+		observer.OnComplete()
+	}()
 }
 
 func (pipeline *Pipeline) Collect() ([]stream.T, error) {
 	var data []stream.T
-	return data, pipeline.CollectAs(&data)
+	err := pipeline.CollectAs(&data)
+	return data, err
 }
 
 func (pipeline *Pipeline) CollectAs(data interface{}) error {
 	return pipeline.Then(consumers.ItemsCollector(data))
 }
 
+// Collects items into a map, e.g. *map[string]User, keyed by the result of fn
+func (pipeline *Pipeline) CollectMapBy(fn stream.MapFn, data interface{}) error {
+	return pipeline.Then(consumers.MapCollector(fn, data))
+}
+
 func (pipeline *Pipeline) CollectFirst() (stream.T, error) {
 	var data stream.T
-	return data, pipeline.CollectFirstAs(&data)
+	err := pipeline.CollectFirstAs(&data)
+	return data, err
 }
 
 func (pipeline *Pipeline) CollectFirstAs(data interface{}) error {
@@ -237,7 +737,8 @@ func (pipeline *Pipeline) CollectFirstAs(data interface{}) error {
 
 func (pipeline *Pipeline) CollectLast() (stream.T, error) {
 	var data stream.T
-	return data, pipeline.CollectLastAs(&data)
+	err := pipeline.CollectLastAs(&data)
+	return data, err
 }
 
 func (pipeline *Pipeline) CollectLastAs(data interface{}) error {
@@ -248,6 +749,7 @@ func (pipeline *Pipeline) CollectBy(fn stream.EachFn) error {
 	return pipeline.Then(consumers.CollectBy(fn))
 }
 
+// Buffers the whole stream and sorts it with a stable sort
 func (pipeline *Pipeline) SortBy(fn stream.SortByFn) ([]stream.T, error) {
 	items, err := pipeline.Collect()
 
@@ -259,16 +761,178 @@ func (pipeline *Pipeline) SortBy(fn stream.SortByFn) ([]stream.T, error) {
 	return items, nil
 }
 
+// Keeps only the first n items of the sorted stream in a bounded heap,
+// using O(n) memory regardless of the stream length. Sorting is stable.
+func (pipeline *Pipeline) SortByLimit(fn stream.SortByFn, n int) ([]stream.T, error) {
+	return pipeline.TopN(n, fn).Collect()
+}
+
 func (pipeline *Pipeline) GroupBy(groupFn stream.MapFn) (stream.Groups, error) {
 	result := make(stream.Groups)
 	return result, pipeline.Then(consumers.GroupBy(groupFn, result))
 }
 
+// Emits a stream.Group for each new key found, forwarding subsequent items
+// with the same key to the group's readable. Groups must be consumed
+// concurrently otherwise a full group blocks the pipeline.
+func (pipeline *Pipeline) GroupByKey(fn stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.GroupByKey(fn))
+}
+
 func (pipeline *Pipeline) Count() (int, error) {
-	items, err := pipeline.Collect()
-	return len(items), err
+	count := 0
+	err := pipeline.Then(consumers.CollectBy(func(stream.T) { count++ }))
+	return count, err
+}
+
+// Same as CollectFirst, production stops as soon as the first item is read
+func (pipeline *Pipeline) First() (stream.T, error) {
+	return pipeline.CollectFirst()
+}
+
+func (pipeline *Pipeline) Last() (stream.T, error) {
+	return pipeline.CollectLast()
+}
+
+// Whether any item matches fn, production stops as soon as one does
+func (pipeline *Pipeline) Any(fn stream.PredicateFn) (bool, error) {
+	found := false
+	err := pipeline.FindBy(fn).Then(consumers.CollectBy(func(stream.T) { found = true }))
+	return found, err
+}
+
+// Whether all items match fn, production stops as soon as one does not
+func (pipeline *Pipeline) All(fn stream.PredicateFn) (bool, error) {
+	found, err := pipeline.Any(func(data stream.T) bool { return !fn(data) })
+	return !found, err
+}
+
+// Whether no item matches fn, production stops as soon as one does
+func (pipeline *Pipeline) None(fn stream.PredicateFn) (bool, error) {
+	found, err := pipeline.Any(fn)
+	return !found, err
+}
+
+// Sums a stream of ints, failing the pipeline in case any other type is found
+func (pipeline *Pipeline) SumInts() (int, error) {
+	sum := 0
+	err := pipeline.Then(consumers.CollectBy(func(data stream.T) { sum += data.(int) }))
+	return sum, err
+}
+
+// Smallest item according to less, the first one in case of ties, or nil
+// in case the stream is empty
+func (pipeline *Pipeline) MinBy(less stream.SortByFn) (stream.T, error) {
+	var min stream.T
+	first := true
+	err := pipeline.Then(consumers.CollectBy(func(data stream.T) {
+		if first || less(data, min) {
+			min, first = data, false
+		}
+	}))
+	return min, err
+}
+
+// Largest item according to less, the first one in case of ties, or nil
+// in case the stream is empty
+func (pipeline *Pipeline) MaxBy(less stream.SortByFn) (stream.T, error) {
+	return pipeline.MinBy(func(a, b stream.T) bool { return less(b, a) })
+}
+
+// Average of a stream of ints, uints or floats of any size, or 0 in case the
+// stream is empty. Non numeric items fail the pipeline.
+func (pipeline *Pipeline) Average() (float64, error) {
+	sum, count := 0.0, 0
+	err := pipeline.Then(consumers.CollectBy(func(data stream.T) {
+		sum += toFloat(data)
+		count++
+	}))
+
+	if err != nil || count == 0 {
+		return 0, err
+	}
+	return sum / float64(count), nil
+}
+
+func toFloat(data stream.T) float64 {
+	value := reflect.ValueOf(data)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	}
+	panic(fmt.Errorf("Not a number: %v", data))
+}
+
+func (pipeline *Pipeline) ToWriter(w io.Writer, fn stream.EncodeFn) error {
+	return pipeline.Then(consumers.ToWriter(w, fn))
+}
+
+func (pipeline *Pipeline) ToCSV(w io.Writer, headers []string) error {
+	return pipeline.Then(consumers.ToCSV(w, headers))
+}
+
+func (pipeline *Pipeline) ToJSONLines(w io.Writer) error {
+	return pipeline.Then(consumers.ToJSONLines(w))
+}
+
+// Inserts items into the database in batches, see consumers.ToSQL. Failed
+// batches are reported on the pipeline's error stream, if requested
+func (pipeline *Pipeline) ToSQL(db *sql.DB, stmt string, options consumers.SQLOptions) error {
+	report := pipeline.reporter("ToSQL", nil)
+	return pipeline.Then(consumers.ToSQL(db, stmt, options, report))
+}
+
+func (pipeline *Pipeline) ToHTTPResponse(w http.ResponseWriter, r *http.Request, fn stream.EncodeFn) error {
+	return pipeline.Then(consumers.ToHTTPResponse(w, r, fn))
+}
+
+func (pipeline *Pipeline) ToFile(pattern string, options consumers.FileOptions) error {
+	return pipeline.Then(consumers.ToFile(pattern, options))
+}
+
+// Consumes the pipeline calling fn concurrently
+// from at most the given number of workers
+func (pipeline *Pipeline) ParallelEach(workers int, fn stream.EachFn) error {
+	return pipeline.Then(consumers.ParallelEach(workers, fn))
 }
 
 func (pipeline *Pipeline) Drain() error {
 	return pipeline.Then(consumers.Drainer())
 }
+
+func (pipeline *Pipeline) DrainWithTimeout(duration time.Duration) error {
+	drained := make(chan error, 1)
+	go func() {
+		drained <- pipeline.Drain()
+	}()
+
+	select {
+	case err := <-drained:
+		return err
+	case <-time.After(duration):
+		pipeline.Context.Close(stream.Timeout)
+		return stream.Timeout
+	}
+}
+
+func (pipeline *Pipeline) ReadWithTimeout(duration time.Duration) (items []stream.T, completed bool) {
+	items = []stream.T{}
+	timeout := time.After(duration)
+
+	for {
+		select {
+		case data, more := <-pipeline.Stream:
+			if !more {
+				return items, true
+			}
+			items = append(items, data)
+		case <-timeout:
+			pipeline.Context.Close(stream.Timeout)
+			return items, false
+		}
+	}
+}
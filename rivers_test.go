@@ -1,17 +1,45 @@
 package rivers_test
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/dispatchers"
 	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/scanners"
 	"github.com/drborges/rivers/stream"
 	"github.com/drborges/rivers/transformers"
 	"github.com/drborges/rivers/transformers/from"
 	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
+type protoMessage struct {
+	body []byte
+}
+
+func (msg *protoMessage) Marshal() ([]byte, error) { return msg.body, nil }
+func (msg *protoMessage) Unmarshal(data []byte) error {
+	msg.body = data
+	return nil
+}
+
 func TestRiversAPI(t *testing.T) {
 	evensOnly := func(data stream.T) bool { return data.(int)%2 == 0 }
 	sum := func(a, b stream.T) stream.T { return a.(int) + b.(int) }
@@ -61,6 +89,31 @@ func TestRiversAPI(t *testing.T) {
 			So(items, ShouldResemble, []stream.T{"a_", "b_", "c_", "d_", "e_"})
 		})
 
+		Convey("From Data -> Flatten Deep", func() {
+			items, err := rivers.FromData([]stream.T{1, 2}, []stream.T{[]stream.T{3}}).FlattenDeep().Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 2, 3})
+		})
+
+		Convey("From Data -> Stable Sort By", func() {
+			type Item struct {
+				Key int
+				ID  string
+			}
+
+			byKey := func(a, b stream.T) bool { return a.(Item).Key < b.(Item).Key }
+			data := []stream.T{Item{2, "a"}, Item{1, "b"}, Item{2, "c"}, Item{1, "d"}, Item{2, "e"}}
+
+			sorted, err := rivers.FromSlice(data).SortBy(byKey)
+			So(err, ShouldBeNil)
+			So(sorted, ShouldResemble, []stream.T{Item{1, "b"}, Item{1, "d"}, Item{2, "a"}, Item{2, "c"}, Item{2, "e"}})
+
+			limited, err := rivers.FromSlice(data).SortByLimit(byKey, 3)
+			So(err, ShouldBeNil)
+			So(limited, ShouldResemble, []stream.T{Item{1, "b"}, Item{1, "d"}, Item{2, "a"}})
+		})
+
 		Convey("From Data -> FlatMap", func() {
 			data, _ := rivers.FromRange(1, 3).
 				FlatMap(func(data stream.T) stream.T { return []stream.T{data, data.(int) + 1} }).
@@ -69,6 +122,34 @@ func TestRiversAPI(t *testing.T) {
 			So(data, ShouldResemble, []stream.T{1, 2, 2, 3, 3, 4})
 		})
 
+		Convey("From Range -> Parallel Filter", func() {
+			sequential, _ := rivers.FromRange(1, 100).Filter(evensOnly).Collect()
+			parallel, err := rivers.FromRange(1, 100).ParallelFilter(4, evensOnly).Collect()
+
+			So(err, ShouldBeNil)
+			So(len(parallel), ShouldEqual, len(sequential))
+			for _, data := range sequential {
+				So(parallel, ShouldContain, data)
+			}
+		})
+
+		Convey("From Range -> Parallel Map", func() {
+			parallel, err := rivers.FromRange(1, 5).ParallelMap(3, add(1)).Collect()
+
+			So(err, ShouldBeNil)
+			So(len(parallel), ShouldEqual, 5)
+			for _, data := range []stream.T{2, 3, 4, 5, 6} {
+				So(parallel, ShouldContain, data)
+			}
+		})
+
+		Convey("From Range -> Parallel Map Ordered", func() {
+			data, err := rivers.FromRange(1, 5).ParallelMapOrdered(3, add(1)).Collect()
+
+			So(err, ShouldBeNil)
+			So(data, ShouldResemble, []stream.T{2, 3, 4, 5, 6})
+		})
+
 		Convey("From Slice -> Dispatch If -> Map", func() {
 			in, out := stream.New(2)
 
@@ -95,6 +176,16 @@ func TestRiversAPI(t *testing.T) {
 			So(combined, ShouldResemble, []stream.T{2, "a_", 3, "b_", 4, "c_", 5})
 		})
 
+		Convey("Zip Pad -> Collect", func() {
+			numbers := rivers.FromData(1, 2, 3)
+			letters := rivers.FromData("a")
+
+			combined, err := numbers.ZipPad("-", letters).Collect()
+
+			So(err, ShouldBeNil)
+			So(combined, ShouldResemble, []stream.T{1, "a", 2, "-", 3, "-"})
+		})
+
 		Convey("Zip By -> Filter -> Collect", func() {
 			numbers := rivers.FromData(1, 2, 3, 4)
 			moreNumbers := rivers.FromData(4, 4, 1)
@@ -118,6 +209,28 @@ func TestRiversAPI(t *testing.T) {
 			So(combined, ShouldContain, 4)
 		})
 
+		Convey("Concat -> Map", func() {
+			numbers := rivers.FromData(1, 2)
+			moreNumbers := rivers.FromData(3, 4)
+
+			combined, err := numbers.Concat(moreNumbers).Map(add(1)).Collect()
+
+			So(err, ShouldBeNil)
+			So(combined, ShouldResemble, []stream.T{2, 3, 4, 5})
+		})
+
+		Convey("Merge Sorted By", func() {
+			ascending := func(a, b stream.T) bool { return a.(int) < b.(int) }
+			odds := rivers.FromData(1, 3, 5, 7)
+			evens := rivers.FromData(2, 4)
+			tens := rivers.FromData(0, 10)
+
+			combined, err := odds.MergeSortedBy(ascending, evens, tens).Collect()
+
+			So(err, ShouldBeNil)
+			So(combined, ShouldResemble, []stream.T{0, 1, 2, 3, 4, 5, 7, 10})
+		})
+
 		Convey("From Data -> Drain", func() {
 			numbers := rivers.FromData(1, 2, 3, 4)
 			numbers.Drain()
@@ -127,6 +240,55 @@ func TestRiversAPI(t *testing.T) {
 			So(opened, ShouldBeFalse)
 		})
 
+		Convey("From Data -> Drain With Timeout", func() {
+			err := rivers.FromData(1, 2, 3, 4).DrainWithTimeout(time.Second)
+
+			So(err, ShouldBeNil)
+		})
+
+		Convey("From Stuck Producer -> Drain With Timeout", func() {
+			stuckProducer := &producers.Observable{
+				Capacity: 1,
+				Emit: func(emitter stream.Emitter) {
+					emitter.Emit(1)
+					time.Sleep(500 * time.Millisecond)
+					emitter.Emit(2)
+				},
+			}
+
+			pipeline := rivers.From(stuckProducer)
+			err := pipeline.DrainWithTimeout(50 * time.Millisecond)
+
+			So(err, ShouldEqual, stream.Timeout)
+			So(pipeline.Context.Err(), ShouldEqual, stream.Timeout)
+		})
+
+		Convey("From Data -> Read With Timeout", func() {
+			items, completed := rivers.FromData(1, 2, 3).ReadWithTimeout(time.Second)
+
+			So(completed, ShouldBeTrue)
+			So(items, ShouldResemble, []stream.T{1, 2, 3})
+		})
+
+		Convey("From Stalled Producer -> Read With Timeout", func() {
+			stalledProducer := &producers.Observable{
+				Capacity: 2,
+				Emit: func(emitter stream.Emitter) {
+					emitter.Emit(1)
+					emitter.Emit(2)
+					time.Sleep(500 * time.Millisecond)
+					emitter.Emit(3)
+				},
+			}
+
+			pipeline := rivers.From(stalledProducer)
+			items, completed := pipeline.ReadWithTimeout(50 * time.Millisecond)
+
+			So(completed, ShouldBeFalse)
+			So(items, ShouldResemble, []stream.T{1, 2})
+			So(pipeline.Context.Err(), ShouldEqual, stream.Timeout)
+		})
+
 		Convey("From Range -> Partition", func() {
 			evensStage, oddsStage := rivers.FromRange(1, 4).Partition(evensOnly)
 			evens, _ := evensStage.Collect()
@@ -139,6 +301,121 @@ func TestRiversAPI(t *testing.T) {
 			So(odds, ShouldContain, 3)
 		})
 
+		Convey("From Range -> Partition -> Map", func() {
+			evensStage, oddsStage := rivers.FromRange(1, 6).Partition(evensOnly)
+			evens, _ := evensStage.Map(func(data stream.T) stream.T { return data.(int) * 10 }).Collect()
+			odds, _ := oddsStage.Map(add(100)).Collect()
+
+			So(len(evens), ShouldEqual, 3)
+			So(evens, ShouldContain, 20)
+			So(evens, ShouldContain, 40)
+			So(evens, ShouldContain, 60)
+
+			So(odds, ShouldResemble, []stream.T{101, 103, 105})
+		})
+
+		Convey("From Range -> Partition -> Filter -> Collect Concurrently", func() {
+			evensStage, oddsStage := rivers.FromRange(1, 5000).WithBufferSize(10).Partition(evensOnly)
+			multipleOfThree := func(data stream.T) bool { return data.(int)%3 == 0 }
+
+			done := make(chan int)
+			go func() {
+				odds, _ := oddsStage.Filter(multipleOfThree).Count()
+				done <- odds
+			}()
+
+			evens, err := evensStage.Filter(multipleOfThree).Count()
+
+			So(err, ShouldBeNil)
+			So(evens, ShouldEqual, 833)
+			So(<-done, ShouldEqual, 833)
+		})
+
+		Convey("From Range -> Broadcast", func() {
+			pipelines := rivers.FromRange(1, 3).Broadcast(2, 1, dispatchers.Block)
+
+			results := make(chan []stream.T)
+			go func() {
+				items, _ := pipelines[1].Collect()
+				results <- items
+			}()
+
+			items, err := pipelines[0].Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 2, 3})
+			So(<-results, ShouldResemble, []stream.T{1, 2, 3})
+		})
+
+		Convey("From Range -> Tee", func() {
+			pipelines := rivers.FromRange(1, 3).Tee(3)
+
+			results := make(chan []stream.T, 2)
+			for _, p := range pipelines[1:] {
+				go func(p *rivers.Pipeline) {
+					items, _ := p.Map(func(data stream.T) stream.T { return data.(int) * 10 }).Collect()
+					results <- items
+				}(p)
+			}
+
+			sum, err := pipelines[0].SumInts()
+
+			So(err, ShouldBeNil)
+			So(sum, ShouldEqual, 6)
+			So(<-results, ShouldResemble, []stream.T{10, 20, 30})
+			So(<-results, ShouldResemble, []stream.T{10, 20, 30})
+		})
+
+		Convey("From Range -> Partition By", func() {
+			mod := func(data stream.T) stream.T { return data.(int) % 4 }
+			pipelines := rivers.FromRange(1, 100).PartitionBy(mod, 3)
+
+			results := make(chan []stream.T, len(pipelines))
+			for _, p := range pipelines {
+				go func(p *rivers.Pipeline) {
+					items, _ := p.Collect()
+					results <- items
+				}(p)
+			}
+
+			total := 0
+			partitions := make(map[stream.T]int)
+			for i := range pipelines {
+				items := <-results
+				total += len(items)
+				for _, item := range items {
+					if _, seen := partitions[mod(item)]; !seen {
+						partitions[mod(item)] = i
+					}
+					So(partitions[mod(item)], ShouldEqual, i)
+				}
+			}
+			So(total, ShouldEqual, 100)
+			So(len(partitions), ShouldEqual, 4)
+		})
+
+		Convey("From Range -> Partition -> Close Context", func() {
+			slowRange := &producers.Observable{
+				Capacity: 1,
+				Emit: func(emitter stream.Emitter) {
+					for i := 1; i <= 1000; i++ {
+						emitter.Emit(i)
+					}
+				},
+			}
+
+			evensStage, oddsStage := rivers.From(slowRange).Partition(evensOnly)
+			evensStage.Context.Close(errors.New("tear down"))
+
+			evens, evensClosed := evensStage.ReadWithTimeout(time.Second)
+			odds, oddsClosed := oddsStage.ReadWithTimeout(time.Second)
+
+			So(evensClosed, ShouldBeTrue)
+			So(oddsClosed, ShouldBeTrue)
+			So(len(evens)+len(odds), ShouldBeLessThan, 1000)
+			So(evensStage.Context.Err().Error(), ShouldEqual, "tear down")
+		})
+
 		Convey("From Range -> Slipt", func() {
 			lhs, rhs := rivers.FromRange(1, 2).Split()
 
@@ -184,6 +461,54 @@ func TestRiversAPI(t *testing.T) {
 			So(taken, ShouldResemble, []stream.T{1, 2})
 		})
 
+		Convey("From Range -> TakeN -> Map -> Collect", func() {
+			taken, err := rivers.FromRange(1, 6).TakeN(2).Map(func(data stream.T) stream.T {
+				return data.(int) * 10
+			}).Collect()
+
+			So(err, ShouldBeNil)
+			So(taken, ShouldResemble, []stream.T{10, 20})
+		})
+
+		Convey("From Generator -> Apply Take -> Map -> Collect", func() {
+			produced := int32(0)
+			taken, err := rivers.FromGenerator(1, func(state stream.T) (stream.T, stream.T, bool) {
+				atomic.AddInt32(&produced, 1)
+				return state, state.(int) + 1, true
+			}).Apply(transformers.Take(2)).Map(func(data stream.T) stream.T {
+				return data.(int) * 10
+			}).Collect()
+
+			So(err, ShouldBeNil)
+			So(taken, ShouldResemble, []stream.T{10, 20})
+
+			Convey("Then the producer stops once the pipeline is consumed", func() {
+				time.Sleep(10 * time.Millisecond)
+				stopped := atomic.LoadInt32(&produced)
+				time.Sleep(10 * time.Millisecond)
+				So(atomic.LoadInt32(&produced), ShouldEqual, stopped)
+			})
+		})
+
+		Convey("From Stalled Producer -> TakeFirst N -> Collect", func() {
+			stalledProducer := &producers.Observable{
+				Capacity: 2,
+				Emit: func(emitter stream.Emitter) {
+					emitter.Emit(1)
+					emitter.Emit(2)
+					time.Sleep(2 * time.Second)
+					emitter.Emit(3)
+				},
+			}
+
+			start := time.Now()
+			taken, err := rivers.From(stalledProducer).TakeFirst(2).Collect()
+
+			So(err, ShouldBeNil)
+			So(taken, ShouldResemble, []stream.T{1, 2})
+			So(time.Since(start).Seconds(), ShouldBeLessThan, 1)
+		})
+
 		Convey("From Range -> Take", func() {
 			pipeline := rivers.FromRange(1, 4).Take(evensOnly)
 
@@ -196,12 +521,134 @@ func TestRiversAPI(t *testing.T) {
 			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{1, 3})
 		})
 
+		Convey("From Range -> Take While", func() {
+			pipeline := rivers.FromRange(1, 6).TakeWhile(func(data stream.T) bool { return data.(int) < 3 })
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{1, 2})
+		})
+
+		Convey("From Range -> Take While -> Map -> Collect", func() {
+			items, err := rivers.FromRange(1, 6).TakeWhile(func(data stream.T) bool {
+				return data.(int) < 3
+			}).Map(func(data stream.T) stream.T {
+				return data.(int) * 10
+			}).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{10, 20})
+		})
+
+		Convey("From Range -> Drop While", func() {
+			pipeline := rivers.FromRange(1, 6).DropWhile(func(data stream.T) bool { return data.(int) < 3 })
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{3, 4, 5, 6})
+		})
+
 		Convey("From Range -> Drop First 2", func() {
 			pipeline := rivers.FromRange(1, 5).DropFirst(2)
 
 			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{3, 4, 5})
 		})
 
+		Convey("From Range -> OnProgress -> Collect", func() {
+			progress := []int{}
+			items, err := rivers.FromRange(1, 7).OnProgress(3, func(count int) {
+				progress = append(progress, count)
+			}).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 2, 3, 4, 5, 6, 7})
+			So(progress, ShouldResemble, []int{3, 6})
+		})
+
+		Convey("From Range -> Sample", func() {
+			pipeline := rivers.FromRange(1, 10).Sample(3)
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{1, 4, 7, 10})
+		})
+
+		Convey("From Data -> Distinct Consecutive", func() {
+			pipeline := rivers.FromData(1, 1, 2, 2, 2, 1).DistinctConsecutive()
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{1, 2, 1})
+		})
+
+		Convey("From Data -> Distinct", func() {
+			pipeline := rivers.FromData(1, 2, 1, 3, 2).Distinct()
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+		})
+
+		Convey("From Data -> Distinct By", func() {
+			toUpper := func(data stream.T) stream.T { return strings.ToUpper(data.(string)) }
+			pipeline := rivers.FromData("a", "A", "b").DistinctBy(toUpper)
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{"a", "b"})
+		})
+
+		Convey("From Data -> Distinct LRU", func() {
+			pipeline := rivers.FromData(1, 2, 3, 1).DistinctLRU(2)
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 1})
+		})
+
+		Convey("From Range -> Throttle", func() {
+			start := time.Now()
+			data, err := rivers.FromRange(1, 3).Throttle(2, 30*time.Millisecond).Collect()
+
+			So(err, ShouldBeNil)
+			So(data, ShouldResemble, []stream.T{1, 2, 3})
+			So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 30*time.Millisecond)
+		})
+
+		Convey("From Range -> Window", func() {
+			pipeline := rivers.FromRange(1, 5).Window(2)
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{
+				[]stream.T{1, 2},
+				[]stream.T{3, 4},
+			})
+		})
+
+		Convey("From Range -> Window Every", func() {
+			pipeline := rivers.FromRange(1, 5).WindowEvery(2, 3)
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{
+				[]stream.T{1, 2},
+				[]stream.T{4, 5},
+			})
+		})
+
+		Convey("From Range -> Sliding Window", func() {
+			pipeline := rivers.FromRange(1, 4).SlidingWindow(3, 1)
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{
+				[]stream.T{1, 2, 3},
+				[]stream.T{2, 3, 4},
+			})
+		})
+
+		Convey("From Range -> With Buffer Size -> Map -> Filter", func() {
+			pipeline := rivers.FromRange(1, 3).WithBufferSize(50)
+			mapped := pipeline.Map(add(1))
+			filtered := mapped.Filter(evensOnly)
+
+			So(pipeline.Stream.Capacity(), ShouldEqual, 50)
+			So(mapped.Stream.Capacity(), ShouldEqual, 50)
+			So(filtered.Stream.Capacity(), ShouldEqual, 50)
+			So(filtered.Stream.ReadAll(), ShouldResemble, []stream.T{2, 4})
+		})
+
+		Convey("From Range -> Filter -> Enumerate", func() {
+			pipeline := rivers.FromRange(1, 6).Filter(evensOnly).Enumerate()
+
+			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{
+				stream.Indexed{Index: 0, Value: 2},
+				stream.Indexed{Index: 1, Value: 4},
+				stream.Indexed{Index: 2, Value: 6},
+			})
+		})
+
 		Convey("From Range -> Collect", func() {
 			data, err := rivers.FromRange(1, 4).Collect()
 
@@ -209,6 +656,58 @@ func TestRiversAPI(t *testing.T) {
 			So(data, ShouldResemble, []stream.T{1, 2, 3, 4})
 		})
 
+		Convey("From Range -> Map -> Collect With Failure", func() {
+			boom := errors.New("boom")
+			failOnThree := func(data stream.T) stream.T {
+				if data == 3 {
+					panic(boom)
+				}
+				return data
+			}
+
+			data, err := rivers.FromRange(1, 5).Map(failOnThree).Collect()
+
+			So(err, ShouldEqual, boom)
+			So(data, ShouldNotContain, 3)
+			So(data, ShouldNotContain, 4)
+			So(data, ShouldNotContain, 5)
+		})
+
+		Convey("From Range -> Map -> On Error", func() {
+			boom := errors.New("boom")
+			failOnThree := func(data stream.T) stream.T {
+				if data == 3 {
+					panic(boom)
+				}
+				return data
+			}
+
+			handled := []error{}
+			pipeline := rivers.FromRange(1, 5)
+			pipeline.Context.OnError(func(err error) {
+				handled = append(handled, err)
+			})
+
+			err := pipeline.Map(failOnThree).Drain()
+
+			So(err, ShouldEqual, boom)
+			So(handled, ShouldResemble, []error{boom})
+		})
+
+		Convey("From Range -> Catch -> Collect", func() {
+			failOnThree := func(data stream.T) stream.T {
+				if data == 3 {
+					panic(errors.New("boom"))
+				}
+				return data
+			}
+
+			data, err := rivers.FromRange(1, 5).Catch(failOnThree, -1).Collect()
+
+			So(err, ShouldBeNil)
+			So(data, ShouldResemble, []stream.T{1, 2, -1, 4, 5})
+		})
+
 		Convey("From Range -> CollectFirst", func() {
 			data, err := rivers.FromRange(1, 4).CollectFirst()
 
@@ -247,6 +746,17 @@ func TestRiversAPI(t *testing.T) {
 			So(numbers, ShouldResemble, []int{1, 2, 3, 4})
 		})
 
+		Convey("From Data -> CollectMapBy", func() {
+			type User struct{ Name string }
+			byName := func(data stream.T) stream.T { return data.(User).Name }
+
+			var users map[string]User
+			err := rivers.FromData(User{"a"}, User{"b"}).CollectMapBy(byName, &users)
+
+			So(err, ShouldBeNil)
+			So(users, ShouldResemble, map[string]User{"a": {"a"}, "b": {"b"}})
+		})
+
 		Convey("From Data -> Map From Struct To JSON", func() {
 			type Account struct{ Name string }
 
@@ -368,6 +878,29 @@ func TestRiversAPI(t *testing.T) {
 			})
 		})
 
+		Convey("From Range -> Group By Key -> Parallel Map", func() {
+			evensAndOdds := func(data stream.T) (key stream.T) {
+				if data.(int)%2 == 0 {
+					return "evens"
+				}
+
+				return "odds"
+			}
+
+			sumGroup := func(data stream.T) stream.T {
+				group := data.(stream.Group)
+				total, _ := rivers.FromChannel(group.Readable).Reduce(0, sum).CollectFirst()
+				return []stream.T{group.Key, total}
+			}
+
+			sums, err := rivers.FromRange(1, 5).GroupByKey(evensAndOdds).ParallelMap(2, sumGroup).Collect()
+
+			So(err, ShouldBeNil)
+			So(len(sums), ShouldEqual, 2)
+			So(sums, ShouldContain, []stream.T{"evens", 6})
+			So(sums, ShouldContain, []stream.T{"odds", 9})
+		})
+
 		Convey("From Reader -> Map -> Filter -> Collect", func() {
 			toString := func(data stream.T) stream.T { return string(data.(byte)) }
 			dashes := func(data stream.T) bool { return data == "-" }
@@ -378,11 +911,755 @@ func TestRiversAPI(t *testing.T) {
 			So(items, ShouldResemble, []stream.T{"a", "b", "c", "d"})
 		})
 
-		Convey("From Range -> Count", func() {
-			count, err := rivers.FromRange(1, 5).Count()
+		Convey("From Channel -> Map -> Collect", func() {
+			ch := make(chan stream.T)
+			go func() {
+				defer close(ch)
+				for i := 1; i <= 3; i++ {
+					ch <- i
+				}
+			}()
+
+			items, err := rivers.FromChannel(ch).Map(add(1)).Collect()
 
 			So(err, ShouldBeNil)
-			So(count, ShouldEqual, 5)
+			So(items, ShouldResemble, []stream.T{2, 3, 4})
 		})
-	})
+
+		Convey("From Any Channel -> Map -> Collect", func() {
+			ch := make(chan int, 3)
+			ch <- 1
+			ch <- 2
+			ch <- 3
+			close(ch)
+
+			items, err := rivers.FromAnyChannel(ch).Map(add(1)).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{2, 3, 4})
+		})
+
+		Convey("From Range -> Reduce By -> Collect", func() {
+			evensAndOdds := func(data stream.T) stream.T {
+				if data.(int)%2 == 0 {
+					return "evens"
+				}
+				return "odds"
+			}
+
+			items, err := rivers.FromRange(1, 5).ReduceBy(evensAndOdds, 0, sum).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{
+				stream.KeyValue{Key: "odds", Value: 9},
+				stream.KeyValue{Key: "evens", Value: 6},
+			})
+		})
+
+		Convey("From Range -> Scan -> Collect", func() {
+			items, err := rivers.FromRange(1, 4).Scan(0, sum).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 3, 6, 10})
+		})
+
+		Convey("From Data -> Reduce1 -> Collect", func() {
+			max := func(a, b stream.T) stream.T {
+				if a.(int) > b.(int) {
+					return a
+				}
+				return b
+			}
+
+			items, err := rivers.FromData(3, 9, 1, 7).Reduce1(max).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{9})
+		})
+
+		Convey("From Data -> TopN -> Collect", func() {
+			ascending := func(a, b stream.T) bool { return a.(int) < b.(int) }
+
+			items, err := rivers.FromData(5, 3, 9, 1, 7).TopN(3, ascending).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 3, 5})
+		})
+
+		Convey("From Channel With Std Context -> Cancel -> Collect", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			ch := make(chan stream.T)
+
+			pipeline := rivers.FromWithContext(rivers.NewContextFrom(ctx), producers.FromChannel(ch))
+			cancel()
+
+			items, err := pipeline.Collect()
+			So(err, ShouldEqual, context.Canceled)
+			So(items, ShouldBeEmpty)
+		})
+
+		Convey("From Range -> Errors -> MapE -> Collect", func() {
+			parse := func(data stream.T) (stream.T, error) {
+				return strconv.Atoi(data.(string))
+			}
+
+			pipeline := rivers.FromData("1", "a", "3")
+			errs := pipeline.Errors()
+
+			failures := make(chan []stream.T)
+			go func() { failures <- errs.ReadAll() }()
+
+			items, err := pipeline.MapE(parse).Collect()
+			reported := <-failures
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 3})
+			So(len(reported), ShouldEqual, 1)
+			So(reported[0].(stream.ItemError).Data, ShouldEqual, "a")
+			So(reported[0].(stream.ItemError).Stage, ShouldEqual, "MapE#1")
+			So(reported[0].(stream.ItemError).Err, ShouldNotBeNil)
+		})
+
+		Convey("From Range -> Named -> Map failing", func() {
+			double := func(data stream.T) stream.T { return data.(int) * 2 }
+			boom := errors.New("boom")
+			_, err := rivers.FromRange(1, 3).Map(double).Named("explode").Map(func(data stream.T) stream.T {
+				panic(boom)
+			}).Collect()
+
+			So(err, ShouldResemble, &stream.StageError{Stage: "explode", Err: boom})
+			So(errors.Is(err, boom), ShouldBeTrue)
+			So(err.Error(), ShouldEqual, "explode: boom")
+		})
+
+		Convey("From Range -> Named -> Map -> Map failing", func() {
+			double := func(data stream.T) stream.T { return data.(int) * 2 }
+			boom := errors.New("boom")
+			_, err := rivers.FromRange(1, 3).Named("double").Map(double).Map(func(data stream.T) stream.T {
+				panic(boom)
+			}).Collect()
+
+			So(err, ShouldEqual, boom)
+		})
+
+		Convey("From Data -> Errors -> Named -> MapE -> Collect", func() {
+			parse := func(data stream.T) (stream.T, error) {
+				return strconv.Atoi(data.(string))
+			}
+
+			pipeline := rivers.FromData("1", "a")
+			errs := pipeline.Errors()
+
+			failures := make(chan []stream.T)
+			go func() { failures <- errs.ReadAll() }()
+
+			items, err := pipeline.Named("parse").MapE(parse).Collect()
+			reported := <-failures
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1})
+			So(len(reported), ShouldEqual, 1)
+			So(reported[0].(stream.ItemError).Stage, ShouldEqual, "parse#1")
+		})
+
+		Convey("From Data -> Dead Letter -> MapE -> Collect", func() {
+			parse := func(data stream.T) (stream.T, error) {
+				return strconv.Atoi(data.(string))
+			}
+
+			var deadLetters []string
+			deadLetter := consumers.CollectBy(func(data stream.T) {
+				serialized, _ := json.Marshal(data)
+				deadLetters = append(deadLetters, string(serialized))
+			})
+
+			items, err := rivers.FromData("1", "a", "3").DeadLetter(deadLetter).MapE(parse).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 3})
+			So(deadLetters, ShouldResemble, []string{
+				`{"data":"a","error":"strconv.Atoi: parsing \"a\": invalid syntax","stage":"MapE#1"}`,
+			})
+		})
+
+		Convey("From Range -> EachE Fail Fast -> Collect", func() {
+			failure := errors.New("failure")
+			items, err := rivers.FromRange(1, 3).EachE(func(data stream.T) error {
+				return failure
+			}, stream.FailFast).Collect()
+
+			So(err, ShouldEqual, failure)
+			So(items, ShouldBeEmpty)
+		})
+
+		Convey("From Range -> Errors -> EachE Continue -> Collect", func() {
+			rejectTwo := func(data stream.T) error {
+				if data == 2 {
+					return errors.New("two")
+				}
+				return nil
+			}
+
+			pipeline := rivers.FromRange(1, 3)
+			errs := pipeline.Errors()
+
+			failures := make(chan []stream.T)
+			go func() { failures <- errs.ReadAll() }()
+
+			items, err := pipeline.EachE(rejectTwo, stream.Continue).Collect()
+			reported := <-failures
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 3})
+			So(len(reported), ShouldEqual, 1)
+			So(reported[0].(stream.ItemError).Data, ShouldEqual, 2)
+			So(reported[0].(stream.ItemError).Stage, ShouldEqual, "EachE#1")
+		})
+
+		Convey("From Range -> EachE Continue -> Collect", func() {
+			items, err := rivers.FromRange(1, 3).EachE(func(data stream.T) error {
+				return errors.New("ignored")
+			}, stream.Continue).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldBeEmpty)
+		})
+
+		Convey("From Range -> MapE -> Collect", func() {
+			failure := errors.New("failure")
+			items, err := rivers.FromRange(1, 3).MapE(func(data stream.T) (stream.T, error) {
+				return nil, failure
+			}).Collect()
+
+			So(err, ShouldEqual, failure)
+			So(items, ShouldBeEmpty)
+		})
+
+		Convey("From Data -> Map With Std Context -> Collect", func() {
+			type key string
+			ctx := context.WithValue(context.Background(), key("user"), "diego")
+			riversCtx := rivers.NewContextFrom(ctx)
+			stdCtx := rivers.ToStdContext(riversCtx)
+
+			items, err := rivers.FromWithContext(riversCtx, producers.FromData(1)).Map(func(data stream.T) stream.T {
+				return stdCtx.Value(key("user"))
+			}).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{"diego"})
+		})
+
+		Convey("From Range -> All With Std Context Per Item", func() {
+			riversCtx := rivers.NewContext()
+			stdCtx := rivers.ToStdContext(riversCtx)
+			goroutines := runtime.NumGoroutine()
+
+			same, err := rivers.FromWithContext(riversCtx, producers.FromRange(1, 10000)).All(func(data stream.T) bool {
+				return rivers.ToStdContext(riversCtx) == stdCtx
+			})
+
+			So(err, ShouldBeNil)
+			So(same, ShouldBeTrue)
+			So(runtime.NumGoroutine(), ShouldBeLessThan, goroutines+10)
+		})
+
+		Convey("Std Context From Failed Context", func() {
+			riversCtx := rivers.NewContext()
+			stdCtx := rivers.ToStdContext(riversCtx)
+			failure := errors.New("failure")
+			riversCtx.Close(failure)
+
+			<-stdCtx.Done()
+			So(stdCtx.Err(), ShouldEqual, context.Canceled)
+			So(context.Cause(stdCtx), ShouldEqual, failure)
+		})
+
+		Convey("From File With Scanner -> Collect", func() {
+			ioutil.WriteFile("/tmp/rivers_from_file_with_scanner", []byte("Hello there\nfolks!"), 0644)
+			defer os.Remove("/tmp/rivers_from_file_with_scanner")
+
+			items, err := rivers.FromFileWithScanner("/tmp/rivers_from_file_with_scanner", bufio.ScanWords).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{"Hello", "there", "folks!"})
+		})
+
+		Convey("From Missing File With Scanner -> Collect", func() {
+			items, err := rivers.FromFileWithScanner("/tmp/rivers_no_such_file", bufio.ScanLines).Collect()
+
+			So(os.IsNotExist(err), ShouldBeTrue)
+			So(items, ShouldBeEmpty)
+		})
+
+		Convey("From Range -> To Writer", func() {
+			var buffer bytes.Buffer
+			err := rivers.FromRange(1, 3).ToWriter(&buffer, consumers.EncodeLines)
+
+			So(err, ShouldBeNil)
+			So(buffer.String(), ShouldEqual, "1\n2\n3\n")
+		})
+
+		Convey("From CSV -> To CSV", func() {
+			var buffer bytes.Buffer
+			input := bytes.NewBufferString("name,age\nDiego,30\n\"Borges, Jr\",3\n")
+			err := rivers.FromCSV(input, producers.CSVOptions{Header: true}).ToCSV(&buffer, []string{"age", "name"})
+
+			So(err, ShouldBeNil)
+			So(buffer.String(), ShouldEqual, "age,name\n30,Diego\n3,\"Borges, Jr\"\n")
+		})
+
+		Convey("From JSON Lines -> To JSON Lines", func() {
+			type user struct {
+				Name string `json:"name"`
+			}
+
+			input := bytes.NewBufferString("{\"name\":\"Diego\"}\n\n{oops}\n{\"name\":\"Borges\"}\n")
+			pipeline := rivers.FromJSONLines(input, func() stream.T { return &user{} })
+			errs := pipeline.Errors()
+
+			failures := make(chan []stream.T)
+			go func() { failures <- errs.ReadAll() }()
+
+			var buffer bytes.Buffer
+			err := pipeline.ToJSONLines(&buffer)
+			reported := <-failures
+
+			So(err, ShouldBeNil)
+			So(buffer.String(), ShouldEqual, "{\"name\":\"Diego\"}\n{\"name\":\"Borges\"}\n")
+			So(len(reported), ShouldEqual, 1)
+			So(reported[0].(stream.ItemError).Data, ShouldEqual, "{oops}")
+			So(reported[0].(stream.ItemError).Stage, ShouldEqual, "FromJSONLines#1")
+		})
+
+		Convey("From JSON Lines -> Collect without consuming errors", func() {
+			input := bytes.NewBufferString(strings.Repeat("not json\n", 5000))
+
+			collected := make(chan error, 1)
+			go func() {
+				_, err := rivers.FromJSONLines(input, func() stream.T { return &map[string]string{} }).Collect()
+				collected <- err
+			}()
+
+			select {
+			case err := <-collected:
+				So(err, ShouldNotBeNil)
+			case <-time.After(3 * time.Second):
+				So("pipeline blocked on unread errors", ShouldBeEmpty)
+			}
+		})
+
+		Convey("From JSON Lines -> MapE -> Collect without consuming errors", func() {
+			input := bytes.NewBufferString("1\n2\n")
+			_, err := rivers.FromJSONLines(input, func() stream.T { var n int; return &n }).MapE(func(data stream.T) (stream.T, error) {
+				return nil, errors.New("boom")
+			}).Collect()
+
+			So(err, ShouldResemble, errors.New("boom"))
+		})
+
+		Convey("From Data -> Marshal Proto -> To Writer -> From Reader -> Unmarshal Proto -> Collect", func() {
+			var buffer bytes.Buffer
+			err := rivers.FromData(&protoMessage{[]byte("hi")}, &protoMessage{[]byte("there")}).
+				MarshalProto().
+				ToWriter(&buffer, nil)
+
+			So(err, ShouldBeNil)
+
+			items, err := rivers.FromReaderWithScanner(&buffer, scanners.NewVarintScanner()).
+				UnmarshalProto(func() transformers.ProtoUnmarshaler { return &protoMessage{} }).
+				Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{&protoMessage{[]byte("hi")}, &protoMessage{[]byte("there")}})
+		})
+
+		Convey("From HTTP -> Collect", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("Hello\nthere"))
+			}))
+			defer server.Close()
+
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			items, err := rivers.FromHTTP(req, bufio.ScanLines, producers.HTTPOptions{}).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{"Hello", "there"})
+		})
+
+		Convey("From SSE -> Collect", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Last-Event-ID") != "" {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.Write([]byte("retry: 1\nid: 1\ndata: hello\n\n"))
+			}))
+			defer server.Close()
+
+			items, err := rivers.FromSSE(server.URL).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{producers.Event{ID: "1", Data: "hello"}})
+		})
+
+		Convey("From TCP Listener -> Take First -> Collect", func() {
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+			pipeline := rivers.FromTCPListener(listener, bufio.ScanLines)
+
+			client, _ := net.Dial("tcp", listener.Addr().String())
+			defer client.Close()
+			client.Write([]byte("a\nb\nc\n"))
+
+			items, err := pipeline.TakeFirst(2).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{"a", "b"})
+		})
+
+		Convey("From Packet Conn -> Map -> Take First -> Collect", func() {
+			conn, _ := net.ListenPacket("udp", "127.0.0.1:0")
+			pipeline := rivers.FromPacketConn(conn)
+
+			client, _ := net.Dial("udp", conn.LocalAddr().String())
+			defer client.Close()
+			client.Write([]byte("a"))
+
+			items, err := pipeline.Map(func(data stream.T) stream.T {
+				return string(data.(producers.Datagram).Data)
+			}).TakeFirst(1).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{"a"})
+		})
+
+		Convey("From UDP -> Collect", func() {
+			_, err := rivers.FromUDP("127.0.0.1:-1").Collect()
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("From Command -> Collect", func() {
+			items, err := rivers.FromCommand(exec.Command("echo", "Hello there"), bufio.ScanWords).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{"Hello", "there"})
+		})
+
+		Convey("From Dir Tree -> Sort By", func() {
+			dir, _ := ioutil.TempDir("", "rivers")
+			defer os.RemoveAll(dir)
+
+			os.MkdirAll(dir+"/nested", 0755)
+			ioutil.WriteFile(dir+"/a.log", nil, 0644)
+			ioutil.WriteFile(dir+"/nested/b.log", nil, 0644)
+			ioutil.WriteFile(dir+"/nested/c.txt", nil, 0644)
+
+			items, err := rivers.FromDirTree(dir, "*.log").SortBy(func(a, b stream.T) bool {
+				return a.(string) < b.(string)
+			})
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{dir + "/a.log", dir + "/nested/b.log"})
+		})
+
+		Convey("From Range -> To HTTP Response", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rivers.FromRange(1, 3).ToHTTPResponse(w, r, consumers.EncodeJSONLines)
+			}))
+			defer server.Close()
+
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			items, err := rivers.FromHTTP(req, bufio.ScanLines, producers.HTTPOptions{}).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{"1", "2", "3"})
+		})
+
+		Convey("From Ticker -> Take First -> Collect", func() {
+			items, err := rivers.FromTicker(time.Millisecond).TakeFirst(2).Collect()
+
+			So(err, ShouldBeNil)
+			So(len(items), ShouldEqual, 2)
+		})
+
+		Convey("From Cron -> Collect", func() {
+			_, err := rivers.FromCron("invalid").Collect()
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("From Generator -> Take First -> Collect", func() {
+			items, err := rivers.FromGenerator(1, func(state stream.T) (stream.T, stream.T, bool) {
+				return state, state.(int) * 2, true
+			}).TakeFirst(4).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 2, 4, 8})
+		})
+
+		Convey("From Pages -> Collect", func() {
+			items, err := rivers.FromPages(func(cursor stream.T) ([]stream.T, stream.T, error) {
+				if cursor == nil {
+					return []stream.T{1, 2}, 2, nil
+				}
+				return []stream.T{3}, 0, nil
+			}).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 2, 3})
+		})
+
+		Convey("From Any Slice -> Collect", func() {
+			type user struct{ Name string }
+			items, err := rivers.FromAnySlice(&[]user{{"a"}, {"b"}}).Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{user{"a"}, user{"b"}})
+		})
+
+		Convey("From Range -> To File", func() {
+			dir, _ := ioutil.TempDir("", "rivers")
+			defer os.RemoveAll(dir)
+
+			err := rivers.FromRange(1, 3).ToFile(dir+"/out.log", consumers.FileOptions{})
+			content, _ := ioutil.ReadFile(dir + "/out.log")
+
+			So(err, ShouldBeNil)
+			So(string(content), ShouldEqual, "1\n2\n3\n")
+		})
+
+		Convey("From Range -> Parallel Each", func() {
+			var mutex sync.Mutex
+			var items []stream.T
+
+			err := rivers.FromRange(1, 10).ParallelEach(3, func(data stream.T) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				items = append(items, data)
+			})
+
+			So(err, ShouldBeNil)
+			So(len(items), ShouldEqual, 10)
+			for i := 1; i <= 10; i++ {
+				So(items, ShouldContain, i)
+			}
+		})
+
+		Convey("From Range -> Count", func() {
+			count, err := rivers.FromRange(1, 5).Count()
+
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 5)
+		})
+
+		naturals := func() *rivers.Pipeline {
+			return rivers.FromGenerator(1, func(state stream.T) (stream.T, stream.T, bool) {
+				return state, state.(int) + 1, true
+			})
+		}
+
+		Convey("From Generator -> First", func() {
+			first, err := naturals().First()
+
+			So(err, ShouldBeNil)
+			So(first, ShouldEqual, 1)
+		})
+
+		Convey("From Range -> Last", func() {
+			last, err := rivers.FromRange(1, 5).Last()
+
+			So(err, ShouldBeNil)
+			So(last, ShouldEqual, 5)
+		})
+
+		Convey("From Generator -> Any", func() {
+			found, err := naturals().Any(func(data stream.T) bool { return data.(int) > 100 })
+
+			So(err, ShouldBeNil)
+			So(found, ShouldBeTrue)
+		})
+
+		Convey("From Range -> Any without matches", func() {
+			found, err := rivers.FromRange(1, 5).Any(func(data stream.T) bool { return data.(int) > 5 })
+
+			So(err, ShouldBeNil)
+			So(found, ShouldBeFalse)
+		})
+
+		Convey("From Generator -> All", func() {
+			all, err := naturals().All(func(data stream.T) bool { return data.(int) < 10 })
+
+			So(err, ShouldBeNil)
+			So(all, ShouldBeFalse)
+		})
+
+		Convey("From Range -> All", func() {
+			all, err := rivers.FromRange(1, 5).All(func(data stream.T) bool { return data.(int) <= 5 })
+
+			So(err, ShouldBeNil)
+			So(all, ShouldBeTrue)
+		})
+
+		Convey("From Range -> None", func() {
+			none, err := rivers.FromRange(1, 5).None(func(data stream.T) bool { return data.(int) > 5 })
+
+			So(err, ShouldBeNil)
+			So(none, ShouldBeTrue)
+		})
+
+		Convey("From Range -> SumInts", func() {
+			sum, err := rivers.FromRange(1, 5).SumInts()
+
+			So(err, ShouldBeNil)
+			So(sum, ShouldEqual, 15)
+		})
+
+		Convey("From Data -> SumInts with non int items", func() {
+			_, err := rivers.FromData(1, "2").SumInts()
+
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("From Data -> MinBy and MaxBy", func() {
+			type Account struct {
+				Name    string
+				Balance int
+			}
+
+			byBalance := func(a, b stream.T) bool { return a.(Account).Balance < b.(Account).Balance }
+			accounts := []stream.T{Account{"a", 3}, Account{"b", 1}, Account{"c", 5}, Account{"d", 1}}
+
+			min, err := rivers.FromData(accounts...).MinBy(byBalance)
+			So(err, ShouldBeNil)
+			So(min, ShouldResemble, Account{"b", 1})
+
+			max, err := rivers.FromData(accounts...).MaxBy(byBalance)
+			So(err, ShouldBeNil)
+			So(max, ShouldResemble, Account{"c", 5})
+		})
+
+		Convey("From Data -> Average", func() {
+			average, err := rivers.FromData(1, int64(2), 3.5, uint8(5)).Average()
+
+			So(err, ShouldBeNil)
+			So(average, ShouldEqual, 2.875)
+		})
+
+		Convey("From Data -> Average of an empty stream", func() {
+			average, err := rivers.FromData().Average()
+
+			So(err, ShouldBeNil)
+			So(average, ShouldEqual, 0)
+		})
+
+		Convey("From Range -> OnComplete -> Finally -> Collect", func() {
+			completed, finished := false, false
+			var result error
+
+			items, err := rivers.FromRange(1, 3).
+				OnComplete(func() { completed = true }).
+				Finally(func(err error) { finished, result = true, err }).
+				Collect()
+
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []stream.T{1, 2, 3})
+			So(completed, ShouldBeTrue)
+			So(finished, ShouldBeTrue)
+			So(result, ShouldBeNil)
+		})
+
+		Convey("From Range -> Map -> OnComplete -> Finally with failure", func() {
+			boom := errors.New("boom")
+			completed := false
+			result := make(chan error, 1)
+
+			_, err := rivers.FromRange(1, 3).Map(func(data stream.T) stream.T {
+				panic(boom)
+			}).OnComplete(func() { completed = true }).Finally(func(err error) { result <- err }).Collect()
+
+			So(err, ShouldEqual, boom)
+			So(<-result, ShouldEqual, boom)
+			So(completed, ShouldBeFalse)
+		})
+
+		Convey("Produced By -> Apply -> Consumed By custom stages", func() {
+			consumer := &summer{}
+			err := rivers.ProducedBy(&counter{to: 3}).Apply(&doubler{}).ConsumedBy(consumer)
+
+			So(err, ShouldBeNil)
+			So(consumer.sum, ShouldEqual, 12)
+		})
+
+		Convey("From Range -> Subscribe", func() {
+			observer := &recorder{done: make(chan bool)}
+			rivers.FromRange(1, 3).Subscribe(observer)
+			<-observer.done
+
+			So(observer.items, ShouldResemble, []stream.T{1, 2, 3})
+			So(observer.err, ShouldBeNil)
+			So(observer.completed, ShouldBeTrue)
+		})
+
+		Convey("From Range -> Map -> Subscribe with failure", func() {
+			observer := &recorder{done: make(chan bool)}
+			rivers.FromRange(1, 3).Map(func(data stream.T) stream.T {
+				if data == 2 {
+					panic(errors.New("boom"))
+				}
+				return data
+			}).Subscribe(observer)
+			<-observer.done
+
+			So(observer.err, ShouldResemble, errors.New("boom"))
+			So(observer.completed, ShouldBeFalse)
+		})
+	})
+}
+
+type recorder struct {
+	items     []stream.T
+	err       error
+	completed bool
+	done      chan bool
+}
+
+func (r *recorder) OnNext(data stream.T) { r.items = append(r.items, data) }
+func (r *recorder) OnError(err error)    { r.err = err; close(r.done) }
+func (r *recorder) OnComplete()          { r.completed = true; close(r.done) }
+
+// Custom stages built on top of stream interfaces only
+type counter struct {
+	context stream.Context
+	to      int
+}
+
+func (c *counter) Attach(context stream.Context) { c.context = context }
+func (c *counter) Produce() stream.Readable {
+	readable, writable := stream.New(c.to)
+	for i := 1; i <= c.to; i++ {
+		writable <- i
+	}
+	close(writable)
+	return readable
+}
+
+type doubler struct{ context stream.Context }
+
+func (d *doubler) Attach(context stream.Context) { d.context = context }
+func (d *doubler) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	go func() {
+		defer close(writable)
+		for data := range in {
+			writable <- data.(int) * 2
+		}
+	}()
+	return readable
+}
+
+type summer struct{ sum int }
+
+func (s *summer) Attach(context stream.Context) {}
+func (s *summer) Consume(in stream.Readable) {
+	for data := range in {
+		s.sum += data.(int)
+	}
 }
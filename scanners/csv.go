@@ -0,0 +1,63 @@
+package scanners
+
+import (
+	"bufio"
+	"errors"
+)
+
+var ErrUnterminatedQuote = errors.New("scanners: unterminated quoted field")
+
+// Splits CSV input into records, keeping quoted fields spanning multiple
+// lines within the same record. Records are returned as is, without the
+// trailing line break, so they can be parsed with encoding/csv.
+func NewCSVScanner(comma rune) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		inQuotes, fieldStart := false, true
+
+		for i := 0; i < len(data); i++ {
+			c := data[i]
+
+			if inQuotes {
+				if c == '"' {
+					if i+1 == len(data) && !atEOF {
+						return 0, nil, nil
+					}
+					if i+1 < len(data) && data[i+1] == '"' {
+						i++
+						continue
+					}
+					inQuotes = false
+				}
+				continue
+			}
+
+			switch {
+			case c == '"' && fieldStart:
+				inQuotes = true
+			case rune(c) == comma:
+				fieldStart = true
+				continue
+			case c == '\n':
+				return i + 1, dropCR(data[:i]), nil
+			}
+			fieldStart = false
+		}
+
+		if !atEOF || len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		if inQuotes {
+			return 0, nil, ErrUnterminatedQuote
+		}
+
+		return len(data), dropCR(data), nil
+	}
+}
+
+func dropCR(data []byte) []byte {
+	if len(data) > 0 && data[len(data)-1] == '\r' {
+		return data[:len(data)-1]
+	}
+	return data
+}
@@ -0,0 +1,42 @@
+package scanners_test
+
+import (
+	"bytes"
+	"github.com/drborges/rivers/scanners"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCSVScanner(t *testing.T) {
+	Convey("Given I have CSV data with quoted fields spanning lines", t, func() {
+		input := "id;bio\r\n1;\"multi\nline; \"\"quoted\"\"\"\n2;plain\"quote\n3;last"
+
+		Convey("When I scan it one byte at a time", func() {
+			tokens, err := scanAll(iotest.OneByteReader(bytes.NewBufferString(input)), scanners.NewCSVScanner(';'))
+
+			Convey("Then each record is scanned as a whole", func() {
+				So(err, ShouldBeNil)
+				So(tokens, ShouldResemble, []string{
+					"id;bio",
+					"1;\"multi\nline; \"\"quoted\"\"\"",
+					"2;plain\"quote",
+					"3;last",
+				})
+			})
+		})
+	})
+
+	Convey("Given I have CSV data with an unterminated quoted field", t, func() {
+		input := "1,ok\n2,\"broken\n"
+
+		Convey("When I scan it", func() {
+			tokens, err := scanAll(bytes.NewBufferString(input), scanners.NewCSVScanner(','))
+
+			Convey("Then the scan fails after the complete records", func() {
+				So(tokens, ShouldResemble, []string{"1,ok"})
+				So(err, ShouldEqual, scanners.ErrUnterminatedQuote)
+			})
+		})
+	})
+}
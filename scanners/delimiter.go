@@ -0,0 +1,54 @@
+package scanners
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+)
+
+// Splits records separated by the given delimiter, e.g. []byte{0} for NUL
+// separated records or []byte("\n\n") for records separated by blank lines
+func NewDelimiterScanner(delim []byte) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.Index(data, delim); i >= 0 {
+			return i + len(delim), data[:i], nil
+		}
+
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+
+		return 0, nil, nil
+	}
+}
+
+// Splits multi-line records starting at lines matching the given regexp,
+// e.g. log entries starting with a timestamp followed by a stack trace.
+// Lines preceding the first match make up a record of their own.
+func NewBoundaryScanner(start *regexp.Regexp) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		for lineStart := bytes.IndexByte(data, '\n') + 1; lineStart > 0 && lineStart < len(data); {
+			lineEnd := bytes.IndexByte(data[lineStart:], '\n')
+			if lineEnd == -1 && !atEOF {
+				return 0, nil, nil
+			}
+
+			line := data[lineStart:]
+			if lineEnd >= 0 {
+				line = data[lineStart : lineStart+lineEnd]
+			}
+
+			if start.Match(dropCR(line)) {
+				return lineStart, dropCR(data[:lineStart-1]), nil
+			}
+
+			lineStart += len(line) + 1
+		}
+
+		if atEOF && len(data) > 0 {
+			return len(data), bytes.TrimRight(data, "\r\n"), nil
+		}
+
+		return 0, nil, nil
+	}
+}
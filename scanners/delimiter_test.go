@@ -0,0 +1,63 @@
+package scanners_test
+
+import (
+	"bytes"
+	"github.com/drborges/rivers/scanners"
+	. "github.com/smartystreets/goconvey/convey"
+	"regexp"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDelimiterScanner(t *testing.T) {
+	Convey("Given I have NUL separated records", t, func() {
+		input := "a\x00multi\nline\x00\x00last"
+
+		Convey("When I scan them one byte at a time", func() {
+			tokens, err := scanAll(iotest.OneByteReader(bytes.NewBufferString(input)), scanners.NewDelimiterScanner([]byte{0}))
+
+			Convey("Then each record is scanned", func() {
+				So(err, ShouldBeNil)
+				So(tokens, ShouldResemble, []string{"a", "multi\nline", "", "last"})
+			})
+		})
+	})
+
+	Convey("Given I have records separated by blank lines", t, func() {
+		input := "first\nparagraph\n\nsecond\n\n"
+
+		Convey("When I scan them", func() {
+			tokens, err := scanAll(bytes.NewBufferString(input), scanners.NewDelimiterScanner([]byte("\n\n")))
+
+			Convey("Then each record is scanned", func() {
+				So(err, ShouldBeNil)
+				So(tokens, ShouldResemble, []string{"first\nparagraph", "second"})
+			})
+		})
+	})
+}
+
+func TestBoundaryScanner(t *testing.T) {
+	timestamp := regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `)
+
+	Convey("Given I have log entries spanning multiple lines", t, func() {
+		input := "preamble\n" +
+			"2016-01-02 panic: boom\r\n\tat main.go:10\n\tat main.go:20\n" +
+			"2016-01-02 info: ok\n" +
+			"2016-01-03 error: failed\ncaused by: timeout\n"
+
+		Convey("When I scan them one byte at a time", func() {
+			tokens, err := scanAll(iotest.OneByteReader(bytes.NewBufferString(input)), scanners.NewBoundaryScanner(timestamp))
+
+			Convey("Then each entry is scanned as a whole", func() {
+				So(err, ShouldBeNil)
+				So(tokens, ShouldResemble, []string{
+					"preamble",
+					"2016-01-02 panic: boom\r\n\tat main.go:10\n\tat main.go:20",
+					"2016-01-02 info: ok",
+					"2016-01-03 error: failed\ncaused by: timeout",
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,133 @@
+package scanners
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidJSON = errors.New("scanners: invalid JSON value")
+
+// Splits a top level JSON array into its elements, or a stream of
+// concatenated JSON values into each value, without buffering the whole
+// document. Elements are returned as raw JSON so they can be decoded with
+// json.Unmarshal. A new scanner must be created for each input.
+func NewJSONScanner() bufio.SplitFunc {
+	started, inArray := false, false
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		i := 0
+		for {
+			i += skip(data[i:], inArray)
+			if i == len(data) {
+				if atEOF && inArray {
+					return i, nil, ErrInvalidJSON
+				}
+				return i, nil, nil
+			}
+
+			if !started {
+				started = true
+				if data[i] == '[' {
+					inArray = true
+					i++
+					continue
+				}
+			}
+
+			if inArray && data[i] == ']' {
+				started, inArray = false, false
+				i++
+				continue
+			}
+
+			break
+		}
+
+		end, complete := valueEnd(data[i:], atEOF)
+		if !complete {
+			if atEOF {
+				return i, nil, ErrInvalidJSON
+			}
+			return i, nil, nil
+		}
+
+		token := data[i : i+end]
+		if !json.Valid(token) {
+			return i, nil, ErrInvalidJSON
+		}
+
+		return i + end, token, nil
+	}
+}
+
+// Returns the number of leading whitespaces, as well as
+// commas in case the input is within an array
+func skip(data []byte, inArray bool) int {
+	i := 0
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n':
+		case ',':
+			if !inArray {
+				return i
+			}
+		default:
+			return i
+		}
+		i++
+	}
+	return i
+}
+
+// Returns the end of the JSON value starting at data[0]
+// and whether the value is complete
+func valueEnd(data []byte, atEOF bool) (int, bool) {
+	switch data[0] {
+	case '{', '[':
+		return compositeEnd(data)
+	case '"':
+		return stringEnd(data)
+	default:
+		end := bytes.IndexAny(data, " \t\r\n,]}{[\"")
+		if end == -1 {
+			return len(data), atEOF
+		}
+		return end, true
+	}
+}
+
+func compositeEnd(data []byte) (int, bool) {
+	depth := 0
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				return i + 1, true
+			}
+		case '"':
+			end, complete := stringEnd(data[i:])
+			if !complete {
+				return 0, false
+			}
+			i += end - 1
+		}
+	}
+	return 0, false
+}
+
+func stringEnd(data []byte) (int, bool) {
+	for i := 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,69 @@
+package scanners_test
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/drborges/rivers/scanners"
+	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func scanAll(r io.Reader, split bufio.SplitFunc) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(split)
+
+	var tokens []string
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Text())
+	}
+	return tokens, scanner.Err()
+}
+
+func TestJSONScanner(t *testing.T) {
+	Convey("Given I have a top level JSON array", t, func() {
+		input := `[ {"name": "Diego", "tags": ["a", "]"]}, "str\"ing", 12.5, null, [1, [2]] ]`
+
+		Convey("When I scan it one byte at a time", func() {
+			tokens, err := scanAll(iotest.OneByteReader(bytes.NewBufferString(input)), scanners.NewJSONScanner())
+
+			Convey("Then each element is scanned as raw JSON", func() {
+				So(err, ShouldBeNil)
+				So(tokens, ShouldResemble, []string{
+					`{"name": "Diego", "tags": ["a", "]"]}`,
+					`"str\"ing"`,
+					`12.5`,
+					`null`,
+					`[1, [2]]`,
+				})
+			})
+		})
+	})
+
+	Convey("Given I have a stream of JSON objects", t, func() {
+		input := "{\"id\": 1}\n{\"id\": 2} {\"id\": 3}"
+
+		Convey("When I scan it", func() {
+			tokens, err := scanAll(bytes.NewBufferString(input), scanners.NewJSONScanner())
+
+			Convey("Then each object is scanned", func() {
+				So(err, ShouldBeNil)
+				So(tokens, ShouldResemble, []string{`{"id": 1}`, `{"id": 2}`, `{"id": 3}`})
+			})
+		})
+	})
+
+	Convey("Given I have a truncated JSON array", t, func() {
+		input := `[{"id": 1}, {"id": `
+
+		Convey("When I scan it", func() {
+			tokens, err := scanAll(bytes.NewBufferString(input), scanners.NewJSONScanner())
+
+			Convey("Then the complete elements are scanned before failing", func() {
+				So(tokens, ShouldResemble, []string{`{"id": 1}`})
+				So(err, ShouldEqual, scanners.ErrInvalidJSON)
+			})
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package scanners
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var ErrTruncatedFrame = errors.New("scanners: truncated frame")
+
+// Splits binary frames made of a length prefix of the given size in bytes,
+// either 1, 2, 4 or 8, followed by that many bytes of payload, which is
+// returned without the prefix. Frames larger than the scanner's buffer,
+// 64KB by default, fail the scan with bufio.ErrTooLong.
+func NewLengthPrefixedScanner(order binary.ByteOrder, prefixSize int) bufio.SplitFunc {
+	var length func([]byte) uint64
+
+	switch prefixSize {
+	case 1:
+		length = func(data []byte) uint64 { return uint64(data[0]) }
+	case 2:
+		length = func(data []byte) uint64 { return uint64(order.Uint16(data)) }
+	case 4:
+		length = func(data []byte) uint64 { return uint64(order.Uint32(data)) }
+	case 8:
+		length = order.Uint64
+	default:
+		panic(fmt.Sprintf("scanners: invalid prefix size %v", prefixSize))
+	}
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if len(data) < prefixSize {
+			return incomplete(data, atEOF)
+		}
+
+		size := length(data)
+		if size > uint64(len(data)-prefixSize) {
+			return incomplete(data, atEOF)
+		}
+
+		end := prefixSize + int(size)
+		return end, data[prefixSize:end], nil
+	}
+}
+
+// Requests more data unless the input is over, in
+// which case any remaining data is a truncated frame
+func incomplete(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) > 0 {
+		return 0, nil, ErrTruncatedFrame
+	}
+	return 0, nil, nil
+}
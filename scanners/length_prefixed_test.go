@@ -0,0 +1,44 @@
+package scanners_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/drborges/rivers/scanners"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLengthPrefixedScanner(t *testing.T) {
+	Convey("Given I have frames prefixed by a 4 bytes big endian length", t, func() {
+		input := []byte{0, 0, 0, 5, 'h', 'e', 'l', 'l', 'o', 0, 0, 0, 0, 0, 0, 0, 2, 0, '\n'}
+
+		Convey("When I scan them one byte at a time", func() {
+			tokens, err := scanAll(iotest.OneByteReader(bytes.NewBuffer(input)), scanners.NewLengthPrefixedScanner(binary.BigEndian, 4))
+
+			Convey("Then each payload is scanned", func() {
+				So(err, ShouldBeNil)
+				So(tokens, ShouldResemble, []string{"hello", "", "\x00\n"})
+			})
+		})
+	})
+
+	Convey("Given I have frames prefixed by a 2 bytes little endian length", t, func() {
+		input := []byte{2, 0, 'h', 'i', 3, 0, 'b', 'y'}
+
+		Convey("When I scan them", func() {
+			tokens, err := scanAll(bytes.NewBuffer(input), scanners.NewLengthPrefixedScanner(binary.LittleEndian, 2))
+
+			Convey("Then the scan fails on the truncated frame", func() {
+				So(tokens, ShouldResemble, []string{"hi"})
+				So(err, ShouldEqual, scanners.ErrTruncatedFrame)
+			})
+		})
+	})
+
+	Convey("Given I have an invalid prefix size", t, func() {
+		Convey("Then the scanner cannot be created", func() {
+			So(func() { scanners.NewLengthPrefixedScanner(binary.BigEndian, 3) }, ShouldPanic)
+		})
+	})
+}
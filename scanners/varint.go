@@ -0,0 +1,28 @@
+package scanners
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+)
+
+var ErrInvalidVarint = errors.New("scanners: invalid varint length prefix")
+
+// Splits frames prefixed by their length encoded as an unsigned varint,
+// as written by protobuf's delimited encoding, returning the payload
+// without the prefix.
+func NewVarintScanner() bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		size, n := binary.Uvarint(data)
+		if n < 0 {
+			return 0, nil, ErrInvalidVarint
+		}
+
+		if n == 0 || size > uint64(len(data)-n) {
+			return incomplete(data, atEOF)
+		}
+
+		end := n + int(size)
+		return end, data[n:end], nil
+	}
+}
@@ -0,0 +1,39 @@
+package scanners_test
+
+import (
+	"bytes"
+	"github.com/drborges/rivers/scanners"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestVarintScanner(t *testing.T) {
+	Convey("Given I have varint delimited frames", t, func() {
+		large := strings.Repeat("x", 300)
+		input := append([]byte{3, 'o', 'n', 'e', 0, 0xAC, 0x02}, large...)
+
+		Convey("When I scan them one byte at a time", func() {
+			tokens, err := scanAll(iotest.OneByteReader(bytes.NewBuffer(input)), scanners.NewVarintScanner())
+
+			Convey("Then each payload is scanned", func() {
+				So(err, ShouldBeNil)
+				So(tokens, ShouldResemble, []string{"one", "", large})
+			})
+		})
+	})
+
+	Convey("Given I have a truncated varint delimited frame", t, func() {
+		input := []byte{3, 'o', 'n', 'e', 0xAC, 0x02, 'x'}
+
+		Convey("When I scan it", func() {
+			tokens, err := scanAll(bytes.NewBuffer(input), scanners.NewVarintScanner())
+
+			Convey("Then the scan fails on the truncated frame", func() {
+				So(tokens, ShouldResemble, []string{"one"})
+				So(err, ShouldEqual, scanners.ErrTruncatedFrame)
+			})
+		})
+	})
+}
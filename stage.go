@@ -0,0 +1,81 @@
+package rivers
+
+import "github.com/drborges/rivers/stream"
+
+// Reusable chain of stages applied to pipelines via Pipeline.Pipe. Stages
+// are immutable, each method returns a new chain extending the current one.
+type Stages struct {
+	steps []func(*Pipeline) *Pipeline
+}
+
+// Starts an empty chain of stages, e.g.:
+//
+//	normalize := rivers.Stage().Map(trim).Filter(nonEmpty)
+//	rivers.FromSlice(lines).Pipe(normalize).Collect()
+func Stage() *Stages {
+	return &Stages{}
+}
+
+// Extends the chain with any pipeline stage, e.g.:
+//
+//	rivers.Stage().With(func(p *rivers.Pipeline) *rivers.Pipeline {
+//		return p.TakeFirst(10)
+//	})
+func (stages *Stages) With(step func(*Pipeline) *Pipeline) *Stages {
+	steps := make([]func(*Pipeline) *Pipeline, len(stages.steps), len(stages.steps)+1)
+	copy(steps, stages.steps)
+	return &Stages{append(steps, step)}
+}
+
+// Applies a new transformer returned by fn each time the chain is
+// piped, since transformers cannot be shared across pipelines
+func (stages *Stages) Apply(fn func() stream.Transformer) *Stages {
+	return stages.With(func(p *Pipeline) *Pipeline { return p.Apply(fn()) })
+}
+
+func (stages *Stages) Map(fn stream.MapFn) *Stages {
+	return stages.With(func(p *Pipeline) *Pipeline { return p.Map(fn) })
+}
+
+func (stages *Stages) Filter(fn stream.PredicateFn) *Stages {
+	return stages.With(func(p *Pipeline) *Pipeline { return p.Filter(fn) })
+}
+
+func (stages *Stages) FlatMap(fn stream.MapFn) *Stages {
+	return stages.With(func(p *Pipeline) *Pipeline { return p.FlatMap(fn) })
+}
+
+func (stages *Stages) Each(fn stream.EachFn) *Stages {
+	return stages.With(func(p *Pipeline) *Pipeline { return p.Each(fn) })
+}
+
+// Extends the chain with another chain of stages
+func (stages *Stages) Pipe(other *Stages) *Stages {
+	return stages.With(func(p *Pipeline) *Pipeline { return p.Pipe(other) })
+}
+
+// Applies the given chain of stages to the pipeline
+func (pipeline *Pipeline) Pipe(stages *Stages) *Pipeline {
+	for _, step := range stages.steps {
+		pipeline = step(pipeline)
+	}
+	return pipeline
+}
+
+// Applies the given chain of stages only if cond holds, e.g.:
+//
+//	throttle := rivers.Stage().With(func(p *rivers.Pipeline) *rivers.Pipeline {
+//		return p.Throttle(100, time.Second)
+//	})
+//	rivers.FromSlice(requests).When(production, throttle)
+func (pipeline *Pipeline) When(cond bool, stages *Stages) *Pipeline {
+	if !cond {
+		return pipeline
+	}
+	return pipeline.Pipe(stages)
+}
+
+// Applies the given chain of stages unless cond holds
+func (pipeline *Pipeline) Unless(cond bool, stages *Stages) *Pipeline {
+	return pipeline.When(!cond, stages)
+}
@@ -0,0 +1,65 @@
+package rivers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestStage(t *testing.T) {
+	trim := func(data stream.T) stream.T { return strings.TrimSpace(data.(string)) }
+	nonEmpty := func(data stream.T) bool { return data != "" }
+	upper := func(data stream.T) stream.T { return strings.ToUpper(data.(string)) }
+
+	Convey("Given I have a reusable chain of stages", t, func() {
+		normalize := rivers.Stage().Map(trim).Filter(nonEmpty)
+
+		Convey("When I pipe it into different pipelines", func() {
+			first, err1 := rivers.FromData(" a ", " ", "b").Pipe(normalize).Collect()
+			second, err2 := rivers.FromData("", " c").Pipe(normalize).Map(upper).Collect()
+
+			Convey("Then each pipeline applies the whole chain", func() {
+				So(err1, ShouldBeNil)
+				So(first, ShouldResemble, []stream.T{"a", "b"})
+				So(err2, ShouldBeNil)
+				So(second, ShouldResemble, []stream.T{"C"})
+			})
+		})
+
+		Convey("When I pipe it conditionally", func() {
+			applied, _ := rivers.FromData(" a ", " ").When(true, normalize).Unless(false, rivers.Stage().Map(upper)).Collect()
+			skipped, _ := rivers.FromData(" a ", " ").When(false, normalize).Unless(true, rivers.Stage().Map(upper)).Collect()
+
+			Convey("Then the chain is only applied if the condition holds", func() {
+				So(applied, ShouldResemble, []stream.T{"A"})
+				So(skipped, ShouldResemble, []stream.T{" a ", " "})
+			})
+		})
+
+		Convey("When I extend it", func() {
+			shout := normalize.Map(upper)
+			firstTwo := rivers.Stage().Apply(func() stream.Transformer { return transformers.TakeFirst(2) })
+
+			Convey("Then the original chain is left untouched", func() {
+				items, _ := rivers.FromData(" a ", "b").Pipe(normalize).Collect()
+				So(items, ShouldResemble, []stream.T{"a", "b"})
+
+				items, _ = rivers.FromData(" a ", "b").Pipe(shout).Collect()
+				So(items, ShouldResemble, []stream.T{"A", "B"})
+			})
+
+			Convey("Then chains can be composed", func() {
+				items, err := rivers.FromData("a", " ", "b", "c").Pipe(shout.Pipe(firstTwo)).Collect()
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, []stream.T{"A", "B"})
+
+				items, err = rivers.FromData("a", " ", "b", "c").Pipe(shout.Pipe(firstTwo)).Collect()
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, []stream.T{"A", "B"})
+			})
+		})
+	})
+}
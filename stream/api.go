@@ -1,7 +1,9 @@
 package stream
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -14,14 +16,34 @@ type T interface{}
 type Readable <-chan T
 type Writable chan<- T
 type MapFn func(T) T
+type MapEFn func(T) (T, error)
 type EachFn func(T)
+type EachEFn func(T) error
+type EncodeFn func(T) ([]byte, error)
 type PredicateFn func(T) bool
 type SortByFn func(a, b T) bool
 type OnDataFn func(data T, emitter Emitter)
 type ReduceFn func(acc, next T) (result T)
+type ProgressFn func(count int)
+type RecoverFn func(err error) T
+type NewFn func() T
+type GeneratorFn func(state T) (item, next T, ok bool)
+type PageFn func(cursor T) (items []T, next T, err error)
+
+// How a stage handles items it fails to process
+type ErrorStrategy int
+
+const (
+	// Closes the context with the failure
+	FailFast ErrorStrategy = iota
+	// Reports the failure to the pipeline's error stream, if any,
+	// and moves on to the next item
+	Continue
+)
 
 type Context interface {
 	Close(err error)
+	OnError(fn func(error))
 	Recover()
 	Err() error
 	Deadline() time.Duration
@@ -73,6 +95,55 @@ type Batch interface {
 	Add(data T)
 }
 
+type Indexed struct {
+	Index int
+	Value T
+}
+
+// Failure processing a single item at a given pipeline stage
+type ItemError struct {
+	Data  T
+	Stage string
+	Err   error
+}
+
+func (err ItemError) Error() string {
+	return fmt.Sprintf("%v: %v", err.Stage, err.Err)
+}
+
+func (err ItemError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"data":  err.Data,
+		"stage": err.Stage,
+		"error": err.Err.Error(),
+	})
+}
+
+// Failure of a named pipeline stage, see rivers.Pipeline.Named
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (err *StageError) Error() string {
+	return fmt.Sprintf("%v: %v", err.Stage, err.Err)
+}
+
+func (err *StageError) Unwrap() error {
+	return err.Err
+}
+
+type KeyValue struct {
+	Key   T
+	Value T
+}
+
+// Sub stream holding all items sharing the same key
+type Group struct {
+	Key      T
+	Readable Readable
+}
+
 type Groups map[T][]T
 
 func (groups Groups) Empty() bool {
@@ -0,0 +1,41 @@
+package stream
+
+import (
+	"math/rand"
+	"time"
+)
+
+type RetryPolicy struct {
+	// Maximum number of consecutive failed attempts
+	// before giving up. Zero means retry forever.
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Fraction of the backoff randomly added to or subtracted
+	// from each delay, ranging from 0 (no jitter) to 1
+	Jitter float64
+}
+
+// Returns how long to wait before the given retry, starting at 1. The
+// backoff doubles on every retry until it reaches MaxBackoff, if set.
+func (policy RetryPolicy) Delay(retry int) time.Duration {
+	delay := policy.Backoff
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if policy.MaxBackoff > 0 && delay >= policy.MaxBackoff {
+			delay = policy.MaxBackoff
+			break
+		}
+	}
+
+	if policy.Jitter > 0 {
+		delay += time.Duration(policy.Jitter * float64(delay) * (2*rand.Float64() - 1))
+	}
+
+	return delay
+}
+
+// Whether or not another attempt is allowed after the given number of failures
+func (policy RetryPolicy) Allows(failures int) bool {
+	return policy.Attempts <= 0 || failures < policy.Attempts
+}
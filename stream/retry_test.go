@@ -0,0 +1,44 @@
+package stream_test
+
+import (
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	Convey("Given I have a retry policy", t, func() {
+		policy := stream.RetryPolicy{Attempts: 3, Backoff: 10 * time.Millisecond, MaxBackoff: 30 * time.Millisecond}
+
+		Convey("Then the backoff doubles on each retry up to the max backoff", func() {
+			So(policy.Delay(1), ShouldEqual, 10*time.Millisecond)
+			So(policy.Delay(2), ShouldEqual, 20*time.Millisecond)
+			So(policy.Delay(3), ShouldEqual, 30*time.Millisecond)
+			So(policy.Delay(10), ShouldEqual, 30*time.Millisecond)
+		})
+
+		Convey("Then attempts are allowed until the limit is reached", func() {
+			So(policy.Allows(2), ShouldBeTrue)
+			So(policy.Allows(3), ShouldBeFalse)
+		})
+
+		Convey("When jitter is set", func() {
+			policy.Jitter = 0.5
+
+			Convey("Then delays vary within the jitter range", func() {
+				for i := 0; i < 20; i++ {
+					So(policy.Delay(1), ShouldBeBetween, 5*time.Millisecond-1, 15*time.Millisecond+1)
+				}
+			})
+		})
+	})
+
+	Convey("Given I have a retry policy without attempts limit", t, func() {
+		policy := stream.RetryPolicy{}
+
+		Convey("Then attempts are always allowed", func() {
+			So(policy.Allows(1000), ShouldBeTrue)
+		})
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"sort"
 )
 
+// Sorts items in place with a stable sort, so items comparing
+// equal keep their original order
 func (by SortByFn) Sort(items []T) {
-	sort.Sort(&sorter{
+	sort.Stable(&sorter{
 		items: items,
 		by:    by,
 	})
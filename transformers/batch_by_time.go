@@ -0,0 +1,85 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type sizeOrTimeBatcher struct {
+	context  stream.Context
+	size     int
+	duration time.Duration
+}
+
+func BatchBySizeOrTime(size int, duration time.Duration) stream.Transformer {
+	return &sizeOrTimeBatcher{
+		size:     size,
+		duration: duration,
+	}
+}
+
+func (batcher *sizeOrTimeBatcher) Attach(context stream.Context) {
+	batcher.context = context
+}
+
+func (batcher *sizeOrTimeBatcher) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(batcher.context, writable)
+
+	go func() {
+		defer batcher.context.Recover()
+		defer close(writable)
+
+		batch := &batch{size: batcher.size}
+
+		// The timer only runs while there is a pending batch, bounding
+		// how long its first item waits before being flushed
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		commit := func(emitter stream.Emitter) {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+
+			if !batch.Empty() {
+				batch.Commit(emitter)
+			}
+		}
+
+		for {
+			select {
+			case <-batcher.context.Failure():
+				return
+			case <-batcher.context.Done():
+				commit(&flushEmitter{batcher.context, writable})
+				return
+			case <-timeout:
+				commit(emitter)
+			case data, more := <-in:
+				if !more {
+					commit(emitter)
+					return
+				}
+
+				if batch.Empty() {
+					timer = time.NewTimer(batcher.duration)
+					timeout = timer.C
+				}
+
+				batch.Add(data)
+				if batch.Full() {
+					commit(emitter)
+				}
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,88 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestBatchBySizeOrTime(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data that fills up a batch", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+
+			Convey("When I apply the batch transformer to the stream", func() {
+				transformer := transformers.BatchBySizeOrTime(2, time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the batch is flushed as soon as it is full", func() {
+					So(<-next, ShouldResemble, []stream.T{1, 2})
+
+					Convey("And the partial batch is flushed once the input is closed", func() {
+						close(out)
+						So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{3}})
+					})
+				})
+			})
+		})
+
+		Convey("And a slow stream of data", func() {
+			in, out := stream.New(1)
+			out <- 1
+
+			Convey("When I apply the batch transformer to the stream", func() {
+				transformer := transformers.BatchBySizeOrTime(10, 50*time.Millisecond)
+				transformer.Attach(context)
+				start := time.Now()
+				next := transformer.Transform(in)
+
+				Convey("Then the partial batch is flushed once the time elapses", func() {
+					So(<-next, ShouldResemble, []stream.T{1})
+					So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+
+					Convey("And no empty batch is emitted", func() {
+						close(out)
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+
+			Convey("When the first item arrives after a while", func() {
+				in, out := stream.New(1)
+				transformer := transformers.BatchBySizeOrTime(10, 50*time.Millisecond)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+				time.Sleep(40 * time.Millisecond)
+				start := time.Now()
+				out <- 1
+
+				Convey("Then the time is measured from the first item of the batch", func() {
+					So(<-next, ShouldResemble, []stream.T{1})
+					So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+					close(out)
+				})
+			})
+
+			Convey("When I close the context with a pending batch", func() {
+				transformer := transformers.BatchBySizeOrTime(10, time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+				time.Sleep(10 * time.Millisecond)
+				context.Close(nil)
+
+				Convey("Then the partial batch is flushed", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{1}})
+				})
+			})
+		})
+	})
+}
@@ -1,11 +1,13 @@
 package transformers_test
 
 import (
+	"errors"
 	"github.com/drborges/rivers"
 	"github.com/drborges/rivers/stream"
 	"github.com/drborges/rivers/transformers"
 	. "github.com/smartystreets/goconvey/convey"
 	"testing"
+	"time"
 )
 
 type batch struct {
@@ -75,5 +77,45 @@ func TestBatcher(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("And a stream of data that is still open", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+
+			Convey("When I apply the flush on close batch transformer to the stream", func() {
+				transformer := transformers.BatchFlushOnClose(2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				So(<-next, ShouldResemble, []stream.T{1, 2})
+				time.Sleep(10 * time.Millisecond)
+
+				Convey("And I close the input stream", func() {
+					close(out)
+
+					Convey("Then the pending partial batch is emitted", func() {
+						So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{3}})
+					})
+				})
+
+				Convey("And I close the context without errors", func() {
+					context.Close(nil)
+
+					Convey("Then the pending partial batch is flushed", func() {
+						So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{3}})
+					})
+				})
+
+				Convey("And I close the context with an error", func() {
+					context.Close(errors.New("failure"))
+
+					Convey("Then the pending partial batch is discarded", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
 	})
 }
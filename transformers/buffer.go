@@ -0,0 +1,139 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type OverflowPolicy int
+
+const (
+	Block OverflowPolicy = iota
+	DropOldest
+	DropNewest
+)
+
+type ring struct {
+	items []stream.T
+	head  int
+	count int
+}
+
+func (r *ring) Full() bool {
+	return r.count == len(r.items)
+}
+
+func (r *ring) Empty() bool {
+	return r.count == 0
+}
+
+func (r *ring) Peek() stream.T {
+	return r.items[r.head]
+}
+
+func (r *ring) Push(data stream.T) {
+	r.items[(r.head+r.count)%len(r.items)] = data
+	r.count++
+}
+
+func (r *ring) Pop() stream.T {
+	data := r.items[r.head]
+	r.items[r.head] = nil
+	r.head = (r.head + 1) % len(r.items)
+	r.count--
+	return data
+}
+
+type buffer struct {
+	context stream.Context
+	size    int
+	policy  OverflowPolicy
+}
+
+func Buffer(size int, policy OverflowPolicy) stream.Transformer {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &buffer{
+		size:   size,
+		policy: policy,
+	}
+}
+
+func (buffer *buffer) Attach(context stream.Context) {
+	buffer.context = context
+}
+
+func (buffer *buffer) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(1)
+	items := &ring{items: make([]stream.T, buffer.size)}
+
+	go func() {
+		defer buffer.context.Recover()
+		defer close(writable)
+
+		input := in
+		for input != nil || !items.Empty() {
+			select {
+			case <-buffer.context.Failure():
+				return
+			case <-buffer.context.Done():
+				return
+			default:
+			}
+
+			// Flush buffered data downstream before taking in more data
+			// so that the overflow policy only kicks in when the next
+			// stage is not keeping up
+			if !items.Empty() {
+				select {
+				case writable <- items.Peek():
+					items.Pop()
+					continue
+				default:
+				}
+			}
+
+			var out stream.Writable
+			var next stream.T
+			if !items.Empty() {
+				out = writable
+				next = items.Peek()
+			}
+
+			from := input
+			if buffer.policy == Block && items.Full() {
+				from = nil
+			}
+
+			select {
+			case <-buffer.context.Failure():
+				return
+			case <-buffer.context.Done():
+				return
+			case <-time.After(buffer.context.Deadline()):
+				panic(stream.Timeout)
+			case out <- next:
+				items.Pop()
+			case data, more := <-from:
+				if !more {
+					input = nil
+					continue
+				}
+
+				if !items.Full() {
+					items.Push(data)
+					continue
+				}
+
+				if buffer.policy == DropOldest {
+					items.Pop()
+					items.Push(data)
+				}
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,79 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestBuffer(t *testing.T) {
+	waitUntilDrained := func(in stream.Readable) {
+		for len(in) > 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply a buffer with the block policy to the stream", func() {
+				transformer := transformers.Buffer(2, transformers.Block)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no item is dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+				})
+			})
+
+			Convey("When I apply a buffer with the drop oldest policy to the stream", func() {
+				transformer := transformers.Buffer(2, transformers.DropOldest)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+				waitUntilDrained(in)
+
+				Convey("Then the oldest buffered items are dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 4, 5})
+				})
+			})
+
+			Convey("When I apply a buffer with the drop newest policy to the stream", func() {
+				transformer := transformers.Buffer(2, transformers.DropNewest)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+				waitUntilDrained(in)
+
+				Convey("Then the incoming items are dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Buffer(2, transformers.Block)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
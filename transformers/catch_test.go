@@ -0,0 +1,72 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestCatch(t *testing.T) {
+	boom := errors.New("boom")
+	failOnTwo := func(data stream.T) stream.T {
+		if data == 2 {
+			panic(boom)
+		}
+		return data.(int) * 10
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+			close(out)
+
+			Convey("When I apply the catch transformer to the stream", func() {
+				transformer := transformers.Catch(failOnTwo, 0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the failing item is replaced by the fallback and processing continues", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{10, 0, 30})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When I apply the catch with transformer to the stream", func() {
+				recovered := []error{}
+				transformer := transformers.CatchWith(failOnTwo, func(err error) stream.T {
+					recovered = append(recovered, err)
+					return err.Error()
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the failing item is replaced by the recover function result", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{10, "boom", 30})
+					So(recovered, ShouldResemble, []error{boom})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Catch(failOnTwo, 0)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
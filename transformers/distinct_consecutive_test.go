@@ -0,0 +1,62 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestDistinctConsecutive(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(6)
+			out <- "a"
+			out <- "a"
+			out <- "B"
+			out <- "b"
+			out <- "b"
+			out <- "a"
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.DistinctConsecutive()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then runs of adjacent equal items are collapsed", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a", "B", "b", "a"})
+				})
+			})
+
+			Convey("When I apply the keyed transformer to the stream", func() {
+				lowerCase := func(data stream.T) stream.T { return strings.ToLower(data.(string)) }
+				transformer := transformers.DistinctConsecutiveBy(lowerCase)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then runs of adjacent items with the same key are collapsed", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a", "B", "a"})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.DistinctConsecutive()
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestDistinct(t *testing.T) {
+	toLower := func(d stream.T) stream.T { return strings.ToLower(d.(string)) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(6)
+			out <- "a"
+			out <- "b"
+			out <- "A"
+			out <- "c"
+			out <- "b"
+			out <- "a"
+			close(out)
+
+			Convey("When I apply the distinct transformer to the stream", func() {
+				transformer := transformers.Distinct()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then repeated items are dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a", "b", "A", "c"})
+				})
+			})
+
+			Convey("When I apply the distinct by transformer to the stream", func() {
+				transformer := transformers.DistinctBy(toLower)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items with repeated keys are dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a", "b", "c"})
+				})
+			})
+
+			Convey("When I apply the bounded distinct by transformer to the stream", func() {
+				transformer := transformers.DistinctByLRU(2, toLower)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only keys among the most recently seen ones are deduplicated", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a", "b", "c", "b", "a"})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Distinct()
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
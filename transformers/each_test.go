@@ -1,6 +1,7 @@
 package transformers_test
 
 import (
+	"errors"
 	"github.com/drborges/rivers"
 	"github.com/drborges/rivers/stream"
 	"github.com/drborges/rivers/transformers"
@@ -39,6 +40,41 @@ func TestEach(t *testing.T) {
 				})
 			})
 
+			Convey("When I apply an error aware transformer reporting failures", func() {
+				var failed []stream.T
+				rejectOdds := func(data stream.T) error {
+					if data.(int)%2 != 0 {
+						return errors.New("odd number")
+					}
+					return nil
+				}
+
+				transformer := transformers.EachE(rejectOdds, func(data stream.T, err error) {
+					failed = append(failed, data)
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only successfully processed items are sent to the next stage", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{2})
+					So(failed, ShouldResemble, []stream.T{1})
+				})
+			})
+
+			Convey("When I apply an error aware transformer without reporting failures", func() {
+				transformer := transformers.EachE(func(data stream.T) error {
+					return errors.New("failure")
+				}, nil)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with the failure", func() {
+					next.ReadAll()
+					<-context.Failure()
+					So(context.Err(), ShouldResemble, errors.New("failure"))
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
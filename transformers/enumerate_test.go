@@ -0,0 +1,51 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestEnumerate(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Enumerate()
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then items are paired with their index starting at zero", func() {
+					So(transformed.ReadAll(), ShouldResemble, []stream.T{
+						stream.Indexed{Index: 0, Value: 1},
+						stream.Indexed{Index: 1, Value: 2},
+						stream.Indexed{Index: 2, Value: 3},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Enumerate()
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
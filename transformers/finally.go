@@ -0,0 +1,56 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type finally struct {
+	context stream.Context
+	fn      func(err error)
+}
+
+// Forwards items untouched calling fn exactly once as soon as the stream
+// ends, either because it was exhausted, the context was closed or failed.
+// fn is given the context error, if any, and runs before the transformed
+// stream is closed so the following stages observe its side effects, unless
+// the pipeline fails in which case they may shut down before fn is called.
+func Finally(fn func(err error)) stream.Transformer {
+	return &finally{fn: fn}
+}
+
+func (finally *finally) Attach(context stream.Context) {
+	finally.context = context
+}
+
+func (finally *finally) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(finally.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer func() {
+			defer finally.context.Recover()
+			finally.fn(finally.context.Err())
+		}()
+		defer finally.context.Recover()
+
+		for {
+			select {
+			case <-finally.context.Failure():
+				return
+			case <-finally.context.Done():
+				return
+			case <-time.After(finally.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+				emitter.Emit(data)
+			}
+		}
+	}()
+
+	return readable
+}
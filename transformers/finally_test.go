@@ -0,0 +1,53 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestFinally(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- 1
+			out <- 2
+			close(out)
+
+			calls := 0
+			var result error
+			transformer := transformers.Finally(func(err error) {
+				calls++
+				result = err
+			})
+			transformer.Attach(context)
+
+			Convey("When I apply the transformer to the stream", func() {
+				next := transformer.Transform(in)
+
+				Convey("Then items are forwarded and fn is called once without errors", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2})
+					So(calls, ShouldEqual, 1)
+					So(result, ShouldBeNil)
+				})
+			})
+
+			Convey("When the context fails", func() {
+				boom := errors.New("boom")
+				context.Close(boom)
+				next := transformer.Transform(in)
+
+				Convey("Then fn is called once with the failure", func() {
+					next.ReadAll()
+					So(calls, ShouldEqual, 1)
+					So(result, ShouldEqual, boom)
+				})
+			})
+		})
+	})
+}
@@ -43,5 +43,24 @@ func TestFlatten(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("And a stream of data with mixed nesting depths", func() {
+			in, out := stream.New(4)
+			out <- []stream.T{1, 2}
+			out <- []stream.T{[]stream.T{3}, []stream.T{[]stream.T{4, 5}}}
+			out <- 6
+			out <- []stream.T{}
+			close(out)
+
+			Convey("When I apply the deep flatten transformer to the stream", func() {
+				transformer := transformers.FlattenDeep()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then all nested items are emitted as scalars", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4, 5, 6})
+				})
+			})
+		})
 	})
 }
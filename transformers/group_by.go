@@ -0,0 +1,65 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type groupBy struct {
+	context stream.Context
+	fn      stream.MapFn
+}
+
+func (groupBy *groupBy) Attach(context stream.Context) {
+	groupBy.context = context
+}
+
+func (groupBy *groupBy) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(groupBy.context, writable)
+	groups := make(map[stream.T]stream.Writable)
+
+	go func() {
+		defer groupBy.context.Recover()
+		defer close(writable)
+		defer func() {
+			for _, group := range groups {
+				close(group)
+			}
+		}()
+
+		for {
+			select {
+			case <-groupBy.context.Failure():
+				return
+			case <-groupBy.context.Done():
+				return
+			case <-time.After(groupBy.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				key := groupBy.fn(data)
+				group, exists := groups[key]
+				if !exists {
+					var readable stream.Readable
+					readable, group = stream.New(in.Capacity())
+					groups[key] = group
+					emitter.Emit(stream.Group{Key: key, Readable: readable})
+				}
+
+				select {
+				case <-groupBy.context.Failure():
+					return
+				case <-groupBy.context.Done():
+					return
+				case group <- data:
+				}
+			}
+		}
+	}()
+
+	return readable
+}
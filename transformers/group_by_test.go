@@ -0,0 +1,58 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestGroupByKey(t *testing.T) {
+	evensAndOdds := func(d stream.T) stream.T { return d.(int) % 2 }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.GroupByKey(evensAndOdds)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then a sub stream is emitted for each key", func() {
+					odds := (<-next).(stream.Group)
+					evens := (<-next).(stream.Group)
+
+					So(odds.Key, ShouldEqual, 1)
+					So(evens.Key, ShouldEqual, 0)
+					So(odds.Readable.ReadAll(), ShouldResemble, []stream.T{1, 3, 5})
+					So(evens.Readable.ReadAll(), ShouldResemble, []stream.T{2, 4})
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.GroupByKey(evensAndOdds)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no group is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
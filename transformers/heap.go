@@ -0,0 +1,82 @@
+package transformers
+
+import (
+	"container/heap"
+	"github.com/drborges/rivers/stream"
+	"sort"
+)
+
+type sequenced struct {
+	seq  int
+	data stream.T
+}
+
+type boundedHeap struct {
+	size  int
+	by    stream.SortByFn
+	seq   int
+	items []sequenced
+}
+
+// Items comparing equal keep their arrival order
+func (h *boundedHeap) before(a, b sequenced) bool {
+	return h.by(a.data, b.data) || !h.by(b.data, a.data) && a.seq < b.seq
+}
+
+func (h *boundedHeap) Len() int {
+	return len(h.items)
+}
+
+// Inverts the given sort function so that the item to be evicted next
+// is always at the root of the heap
+func (h *boundedHeap) Less(i, j int) bool {
+	return h.before(h.items[j], h.items[i])
+}
+
+func (h *boundedHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *boundedHeap) Push(item interface{}) {
+	h.items = append(h.items, item.(sequenced))
+}
+
+func (h *boundedHeap) Pop() interface{} {
+	last := len(h.items) - 1
+	item := h.items[last]
+	h.items = h.items[:last]
+	return item
+}
+
+func (h *boundedHeap) Add(data stream.T) {
+	if h.size <= 0 {
+		return
+	}
+
+	item := sequenced{h.seq, data}
+	h.seq++
+
+	if h.Len() < h.size {
+		heap.Push(h, item)
+		return
+	}
+
+	if h.before(item, h.items[0]) {
+		h.items[0] = item
+		heap.Fix(h, 0)
+	}
+}
+
+func (h *boundedHeap) Sorted() []stream.T {
+	items := make([]sequenced, len(h.items))
+	copy(items, h.items)
+	sort.Slice(items, func(i, j int) bool {
+		return h.before(items[i], items[j])
+	})
+
+	sorted := make([]stream.T, len(items))
+	for i, item := range items {
+		sorted[i] = item.data
+	}
+	return sorted
+}
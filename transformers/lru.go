@@ -0,0 +1,38 @@
+package transformers
+
+import (
+	"container/list"
+	"github.com/drborges/rivers/stream"
+)
+
+// Set of keys evicting the least recently seen key once
+// its size is reached. A size <= 0 means unbounded.
+type lru struct {
+	size  int
+	order *list.List
+	keys  map[stream.T]*list.Element
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:  size,
+		order: list.New(),
+		keys:  make(map[stream.T]*list.Element),
+	}
+}
+
+// Adds the key to the set returning whether or not it was already present
+func (lru *lru) Add(key stream.T) bool {
+	if element, exists := lru.keys[key]; exists {
+		lru.order.MoveToFront(element)
+		return true
+	}
+
+	lru.keys[key] = lru.order.PushFront(key)
+	if lru.size > 0 && lru.order.Len() > lru.size {
+		oldest := lru.order.Back()
+		lru.order.Remove(oldest)
+		delete(lru.keys, oldest.Value)
+	}
+	return false
+}
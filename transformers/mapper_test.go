@@ -1,6 +1,7 @@
 package transformers_test
 
 import (
+	"errors"
 	"github.com/drborges/rivers"
 	"github.com/drborges/rivers/stream"
 	"github.com/drborges/rivers/transformers"
@@ -10,6 +11,12 @@ import (
 
 func TestMapper(t *testing.T) {
 	inc := func(d stream.T) stream.T { return d.(int) + 1 }
+	incOdds := func(d stream.T) (stream.T, error) {
+		if d.(int)%2 == 0 {
+			return nil, errors.New("even number")
+		}
+		return d.(int) + 1, nil
+	}
 
 	Convey("Given I have a context", t, func() {
 		context := rivers.NewContext()
@@ -30,6 +37,32 @@ func TestMapper(t *testing.T) {
 				})
 			})
 
+			Convey("When I apply an error aware mapper transformer reporting failures", func() {
+				var failed []stream.T
+				transformer := transformers.MapE(incOdds, func(data stream.T, err error) {
+					failed = append(failed, data)
+				})
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then failed items are reported and the remaining ones transformed", func() {
+					So(transformed.ReadAll(), ShouldResemble, []stream.T{2})
+					So(failed, ShouldResemble, []stream.T{2})
+				})
+			})
+
+			Convey("When I apply an error aware mapper transformer without reporting failures", func() {
+				transformer := transformers.MapE(incOdds, nil)
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then the context is closed with the failure", func() {
+					transformed.ReadAll()
+					<-context.Failure()
+					So(context.Err(), ShouldResemble, errors.New("even number"))
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
@@ -6,9 +6,14 @@ import (
 )
 
 type Observer struct {
-	context     stream.Context
+	context stream.Context
+	// Capacity of the transformed stream, defaults to the input stream capacity
+	Capacity    int
 	OnCompleted func(emitter stream.Emitter)
 	OnNext      func(data stream.T, emitter stream.Emitter) error
+	// Called when the context is closed without errors, allowing any
+	// pending state to be flushed downstream before shutting down
+	OnDone func(emitter stream.Emitter)
 }
 
 func (observer *Observer) Attach(context stream.Context) {
@@ -16,23 +21,51 @@ func (observer *Observer) Attach(context stream.Context) {
 }
 
 func (observer *Observer) Transform(in stream.Readable) stream.Readable {
-	readable, writable := stream.New(in.Capacity())
+	capacity := observer.Capacity
+	if capacity <= 0 {
+		capacity = in.Capacity()
+	}
+
+	readable, writable := stream.New(capacity)
 	emitter := stream.NewEmitter(observer.context, writable)
 
 	go func() {
-		defer observer.context.Recover()
+		// Failures are recorded before the stream is closed so
+		// the following stages can tell how it ended
 		defer close(writable)
+		defer observer.context.Recover()
+
+		flush := func() {
+			if observer.OnDone != nil {
+				observer.OnDone(&flushEmitter{observer.context, writable})
+			}
+		}
 
 		for {
 			select {
 			case <-observer.context.Failure():
 				return
 			case <-observer.context.Done():
+				flush()
 				return
 			case <-time.After(observer.context.Deadline()):
 				panic(stream.Timeout)
 			default:
-				data, more := <-in
+				var data stream.T
+				var more bool
+
+				// Keeps listening to the context while waiting for data so
+				// a stalled upstream does not prevent the observer from
+				// shutting down
+				select {
+				case <-observer.context.Failure():
+					return
+				case <-observer.context.Done():
+					flush()
+					return
+				case data, more = <-in:
+				}
+
 				if !more {
 					if observer.OnCompleted != nil {
 						observer.OnCompleted(emitter)
@@ -46,8 +79,10 @@ func (observer *Observer) Transform(in stream.Readable) stream.Readable {
 
 				if err := observer.OnNext(data, emitter); err != nil {
 					if err == stream.Done {
-						// Tell producer to shutdown without errors
-						observer.context.Close(nil)
+						// Ends this stage only so the following stages still
+						// process what was emitted. Upstream is drained until
+						// the context is closed, see rivers.Pipeline.Then.
+						go discard(observer.context, in)
 						return
 					}
 					panic(err)
@@ -58,3 +93,37 @@ func (observer *Observer) Transform(in stream.Readable) stream.Readable {
 
 	return readable
 }
+
+// Emits data even though the context is done, as long as
+// it has not failed
+type flushEmitter struct {
+	context  stream.Context
+	writable stream.Writable
+}
+
+func (emitter *flushEmitter) Emit(data stream.T) {
+	select {
+	case <-emitter.context.Failure():
+		panic(stream.Done)
+	case <-time.After(emitter.context.Deadline()):
+		panic(stream.Timeout)
+	case emitter.writable <- data:
+	}
+}
+
+// Reads and drops the remaining data until the stream is
+// closed or the context is closed
+func discard(context stream.Context, in stream.Readable) {
+	for {
+		select {
+		case <-context.Failure():
+			return
+		case <-context.Done():
+			return
+		case _, more := <-in:
+			if !more {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestOnProgress(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				progress := []int{}
+				transformer := transformers.OnProgress(2, func(count int) {
+					progress = append(progress, count)
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are passed through unchanged", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+
+					Convey("And the progress callback is called every n items", func() {
+						So(progress, ShouldResemble, []int{2, 4})
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.OnProgress(1, func(count int) {})
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,152 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"time"
+)
+
+type parallel struct {
+	context stream.Context
+	workers int
+	OnNext  func(data stream.T, emitter stream.Emitter)
+}
+
+func (parallel *parallel) Attach(context stream.Context) {
+	parallel.context = context
+}
+
+func (parallel *parallel) Transform(in stream.Readable) stream.Readable {
+	workers := parallel.workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(parallel.context, writable)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer parallel.context.Recover()
+			defer wg.Done()
+
+			for {
+				select {
+				case <-parallel.context.Failure():
+					return
+				case <-parallel.context.Done():
+					return
+				case <-time.After(parallel.context.Deadline()):
+					panic(stream.Timeout)
+				case data, more := <-in:
+					if !more {
+						return
+					}
+					parallel.OnNext(data, emitter)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(writable)
+		wg.Wait()
+	}()
+
+	return readable
+}
+
+// Same as parallel but emits results in the same order items
+// were read, keeping at most workers items in flight
+type orderedParallel struct {
+	context stream.Context
+	workers int
+	fn      stream.MapFn
+}
+
+type job struct {
+	data   stream.T
+	result chan stream.T
+}
+
+func (parallel *orderedParallel) Attach(context stream.Context) {
+	parallel.context = context
+}
+
+func (parallel *orderedParallel) Transform(in stream.Readable) stream.Readable {
+	workers := parallel.workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(parallel.context, writable)
+	jobs := make(chan job, workers)
+	pending := make(chan chan stream.T, workers)
+
+	go func() {
+		defer parallel.context.Recover()
+		defer close(jobs)
+		defer close(pending)
+
+		for {
+			select {
+			case <-parallel.context.Failure():
+				return
+			case <-parallel.context.Done():
+				return
+			case <-time.After(parallel.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				job := job{data, make(chan stream.T, 1)}
+				select {
+				case <-parallel.context.Failure():
+					return
+				case <-parallel.context.Done():
+					return
+				case pending <- job.result:
+				}
+
+				select {
+				case <-parallel.context.Failure():
+					return
+				case jobs <- job:
+				}
+			}
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer parallel.context.Recover()
+
+			for job := range jobs {
+				job.result <- parallel.fn(job.data)
+			}
+		}()
+	}
+
+	go func() {
+		defer parallel.context.Recover()
+		defer close(writable)
+
+		for result := range pending {
+			select {
+			case <-parallel.context.Failure():
+				return
+			case <-parallel.context.Done():
+				return
+			case data := <-result:
+				emitter.Emit(data)
+			}
+		}
+	}()
+
+	return readable
+}
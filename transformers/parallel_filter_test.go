@@ -0,0 +1,54 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	"github.com/smartystreets/assertions/should"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestParallelFilter(t *testing.T) {
+	evens := func(d stream.T) bool { return d.(int)%2 == 0 }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(4)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.ParallelFilter(3, evens)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only items matching the predicate are emitted", func() {
+					data := next.ReadAll()
+					So(len(data), ShouldEqual, 2)
+					So(data, should.Contain, 2)
+					So(data, should.Contain, 4)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.ParallelFilter(3, evens)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	"github.com/smartystreets/assertions/should"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	// Sleeps longer for smaller numbers so results are
+	// produced out of order by the workers
+	slowDouble := func(d stream.T) stream.T {
+		time.Sleep(time.Duration(5-d.(int)) * 5 * time.Millisecond)
+		return d.(int) * 2
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(4)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			close(out)
+
+			Convey("When I apply the parallel map transformer to the stream", func() {
+				transformer := transformers.ParallelMap(4, slowDouble)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then all items are transformed", func() {
+					data := next.ReadAll()
+					So(len(data), ShouldEqual, 4)
+					So(data, should.Contain, 2)
+					So(data, should.Contain, 4)
+					So(data, should.Contain, 6)
+					So(data, should.Contain, 8)
+				})
+			})
+
+			Convey("When I apply the ordered parallel map transformer to the stream", func() {
+				transformer := transformers.ParallelMapOrdered(4, slowDouble)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are transformed preserving the input order", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{2, 4, 6, 8})
+				})
+			})
+
+			Convey("When the map function panics", func() {
+				transformer := transformers.ParallelMapOrdered(2, func(d stream.T) stream.T {
+					if d.(int) == 2 {
+						panic(errors.New("boom"))
+					}
+					return d
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with the failure", func() {
+					next.ReadAll()
+					<-context.Failure()
+					So(context.Err(), ShouldNotBeNil)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the ordered parallel map transformer to the stream", func() {
+					transformer := transformers.ParallelMapOrdered(2, slowDouble)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
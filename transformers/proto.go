@@ -0,0 +1,65 @@
+package transformers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/drborges/rivers/stream"
+)
+
+// Implemented by generated protobuf messages, e.g. gogo/protobuf
+type ProtoMarshaler interface {
+	Marshal() ([]byte, error)
+}
+
+type ProtoUnmarshaler interface {
+	Unmarshal([]byte) error
+}
+
+// Unmarshals each []byte or string payload, for instance framed by
+// scanners.NewVarintScanner, into a new message returned by fn
+func UnmarshalProto(fn func() ProtoUnmarshaler) stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			var payload []byte
+			switch data := data.(type) {
+			case []byte:
+				payload = data
+			case string:
+				payload = []byte(data)
+			default:
+				return fmt.Errorf("cannot unmarshal %T as a proto message", data)
+			}
+
+			msg := fn()
+			if err := msg.Unmarshal(payload); err != nil {
+				return err
+			}
+
+			emitter.Emit(msg)
+			return nil
+		},
+	}
+}
+
+// Marshals each message prefixed by its varint encoded length so
+// the resulting frames can be written as a delimited stream
+func MarshalProto() stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			msg, ok := data.(ProtoMarshaler)
+			if !ok {
+				return fmt.Errorf("cannot marshal %T as a proto message", data)
+			}
+
+			payload, err := msg.Marshal()
+			if err != nil {
+				return err
+			}
+
+			frame := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(payload))
+			n := binary.PutUvarint(frame, uint64(len(payload)))
+			emitter.Emit(append(frame[:n], payload...))
+			return nil
+		},
+	}
+}
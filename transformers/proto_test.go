@@ -0,0 +1,88 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type message struct {
+	Body string
+}
+
+func (msg *message) Marshal() ([]byte, error) {
+	if msg.Body == "" {
+		return nil, errors.New("empty message")
+	}
+	return []byte(msg.Body), nil
+}
+
+func (msg *message) Unmarshal(data []byte) error {
+	msg.Body = string(data)
+	return nil
+}
+
+func TestProto(t *testing.T) {
+	newMessage := func() transformers.ProtoUnmarshaler { return &message{} }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of messages", func() {
+			in, out := stream.New(2)
+			out <- &message{"hi"}
+			out <- &message{"there"}
+			close(out)
+
+			Convey("When I apply the marshal transformer", func() {
+				transformer := transformers.MarshalProto()
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then messages are marshaled into varint delimited frames", func() {
+					So(transformed.ReadAll(), ShouldResemble, []stream.T{
+						[]byte("\x02hi"),
+						[]byte("\x05there"),
+					})
+				})
+			})
+		})
+
+		Convey("And a stream of invalid messages", func() {
+			in, out := stream.New(1)
+			out <- &message{}
+			close(out)
+
+			Convey("When I apply the marshal transformer", func() {
+				transformer := transformers.MarshalProto()
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then the context is closed with the failure", func() {
+					transformed.ReadAll()
+					So(context.Err(), ShouldResemble, errors.New("empty message"))
+				})
+			})
+		})
+
+		Convey("And a stream of payloads", func() {
+			in, out := stream.New(2)
+			out <- "hi"
+			out <- []byte("there")
+			close(out)
+
+			Convey("When I apply the unmarshal transformer", func() {
+				transformer := transformers.UnmarshalProto(newMessage)
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then payloads are unmarshaled into messages", func() {
+					So(transformed.ReadAll(), ShouldResemble, []stream.T{&message{"hi"}, &message{"there"}})
+				})
+			})
+		})
+	})
+}
@@ -31,6 +31,40 @@ func TestReducer(t *testing.T) {
 				})
 			})
 
+			Convey("When I apply a reduce by key transformer to the stream", func() {
+				oddOrEven := func(d stream.T) stream.T { return d.(int) % 2 }
+				transformer := transformers.ReduceBy(oddOrEven, 10, sum)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then an accumulation is emitted per key", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						stream.KeyValue{Key: 1, Value: 14},
+						stream.KeyValue{Key: 0, Value: 12},
+					})
+				})
+			})
+
+			Convey("When I apply a scan transformer to the stream", func() {
+				transformer := transformers.Scan(10, sum)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then every intermediate accumulation is emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{11, 13, 16})
+				})
+			})
+
+			Convey("When I apply a seedless reducer transformer to the stream", func() {
+				transformer := transformers.Reduce1(sum)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the first item is used as the initial accumulator", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{6})
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
@@ -46,4 +80,39 @@ func TestReducer(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream with a single item", func() {
+			in, out := stream.New(1)
+			out <- 7
+			close(out)
+
+			Convey("When I apply a seedless reducer transformer to the stream", func() {
+				transformer := transformers.Reduce1(sum)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the item is emitted unchanged", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{7})
+				})
+			})
+		})
+
+		Convey("And an empty stream", func() {
+			in, out := stream.New(0)
+			close(out)
+
+			Convey("When I apply a seedless reducer transformer to the stream", func() {
+				transformer := transformers.Reduce1(sum)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no item is emitted", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
 }
@@ -0,0 +1,74 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestRetryBy(t *testing.T) {
+	policy := stream.RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- 1
+			out <- 2
+			close(out)
+
+			Convey("When I apply the transformer with a function failing transiently", func() {
+				calls := map[stream.T]int{}
+				flaky := func(d stream.T) (stream.T, error) {
+					calls[d]++
+					if calls[d] < 3 {
+						return nil, errors.New("try again")
+					}
+					return d.(int) * 10, nil
+				}
+
+				transformer := transformers.RetryBy(flaky, policy, nil)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are transformed once the function succeeds", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{10, 20})
+					So(calls, ShouldResemble, map[stream.T]int{1: 3, 2: 3})
+				})
+			})
+
+			Convey("When I apply the transformer with a function that always fails", func() {
+				var reported []stream.T
+				failing := func(d stream.T) (stream.T, error) { return nil, errors.New("boom") }
+				transformer := transformers.RetryBy(failing, policy, func(d stream.T, err error) {
+					reported = append(reported, d)
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are reported once attempts are exhausted", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(reported, ShouldResemble, []stream.T{1, 2})
+				})
+			})
+
+			Convey("When I close the context while the transformer is backing off", func() {
+				failing := func(d stream.T) (stream.T, error) { return nil, errors.New("boom") }
+				transformer := transformers.RetryBy(failing, stream.RetryPolicy{Backoff: time.Hour}, nil)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+				time.Sleep(10 * time.Millisecond)
+				context.Close(nil)
+
+				Convey("Then the transformer stops right away", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Sample(2)
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then a transformed stream is returned", func() {
+					So(transformed.ReadAll(), ShouldResemble, []stream.T{1, 3})
+				})
+			})
+
+			Convey("When I apply the transformer with n less than or equal to 1", func() {
+				transformer := transformers.Sample(1)
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then all items are passed through", func() {
+					So(transformed.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Sample(1)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,26 @@
+package transformers
+
+import "github.com/drborges/rivers/stream"
+
+// Stateful allows custom transformations holding state across items such
+// as windowing, running aggregations and deduplication.
+//
+// OnData is called for every item read from the stream and OnEnd once the
+// stream is exhausted, allowing any pending state to be flushed. Emitting
+// data after the context is closed causes the emitter to panic with
+// stream.Done (or stream.Timeout), which must not be recovered by
+// implementations so the pipeline can shut down properly.
+type Stateful interface {
+	OnData(data stream.T, emitter stream.Emitter)
+	OnEnd(emitter stream.Emitter)
+}
+
+func FromStateful(s Stateful) stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			s.OnData(data, emitter)
+			return nil
+		},
+		OnCompleted: s.OnEnd,
+	}
+}
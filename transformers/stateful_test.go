@@ -0,0 +1,85 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type runningSum struct {
+	sum int
+}
+
+func (s *runningSum) OnData(data stream.T, emitter stream.Emitter) {
+	s.sum += data.(int)
+	emitter.Emit(s.sum)
+}
+
+func (s *runningSum) OnEnd(emitter stream.Emitter) {
+	emitter.Emit("total")
+}
+
+// Emits the smallest item seen so far for each item
+type minSoFar struct {
+	min  int
+	seen bool
+}
+
+func (m *minSoFar) OnData(data stream.T, emitter stream.Emitter) {
+	if n := data.(int); !m.seen || n < m.min {
+		m.min, m.seen = n, true
+	}
+	emitter.Emit(m.min)
+}
+
+func (m *minSoFar) OnEnd(emitter stream.Emitter) {}
+
+func TestStateful(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 3
+			out <- 1
+			out <- 2
+			close(out)
+
+			Convey("When I apply a stateful transformer to the stream", func() {
+				transformer := transformers.FromStateful(&runningSum{})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then a transformed stream is returned", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{3, 4, 6, "total"})
+				})
+			})
+
+			Convey("When I apply a min so far transformer to the stream", func() {
+				transformer := transformers.FromStateful(&minSoFar{})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the minimum is emitted for each item", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{3, 1, 1})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.FromStateful(&runningSum{})
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
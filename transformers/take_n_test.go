@@ -6,6 +6,7 @@ import (
 	"github.com/drborges/rivers/transformers"
 	. "github.com/smartystreets/goconvey/convey"
 	"testing"
+	"time"
 )
 
 func TestTakeN(t *testing.T) {
@@ -29,6 +30,27 @@ func TestTakeN(t *testing.T) {
 				})
 			})
 
+			Convey("When I apply the take transformer to the stream", func() {
+				transformer := transformers.Take(2)
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then a transformed stream is returned", func() {
+					So(transformed.ReadAll(), ShouldResemble, []stream.T{1, 2})
+
+					Convey("And the remaining items are discarded leaving the context open", func() {
+						time.Sleep(10 * time.Millisecond)
+						So(len(in), ShouldEqual, 0)
+
+						select {
+						case <-context.Done():
+							So("context closed", ShouldBeEmpty)
+						default:
+						}
+					})
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
@@ -0,0 +1,70 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestTakeWhile(t *testing.T) {
+	odds := func(d stream.T) bool { return d.(int)%2 != 0 }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(4)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.TakeWhile(odds)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then leading items are taken until the predicate fails", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1})
+				})
+			})
+
+			Convey("When the predicate is always true", func() {
+				transformer := transformers.TakeWhile(func(stream.T) bool { return true })
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then all items are taken", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4})
+				})
+			})
+
+			Convey("When the predicate is false for the first item", func() {
+				transformer := transformers.TakeWhile(func(stream.T) bool { return false })
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no item is taken", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.TakeWhile(odds)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type throttle struct {
+	context stream.Context
+	n       int
+	per     time.Duration
+}
+
+func (throttle *throttle) Attach(context stream.Context) {
+	throttle.context = context
+}
+
+func (throttle *throttle) Transform(in stream.Readable) stream.Readable {
+	n := throttle.n
+	if n <= 0 {
+		n = 1
+	}
+
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(throttle.context, writable)
+
+	go func() {
+		defer throttle.context.Recover()
+		defer close(writable)
+
+		// Times of the last n emissions, oldest first
+		emitted := make([]time.Time, 0, n)
+
+		for {
+			select {
+			case <-throttle.context.Failure():
+				return
+			case <-throttle.context.Done():
+				return
+			case <-time.After(throttle.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				if len(emitted) == n {
+					if wait := emitted[0].Add(throttle.per).Sub(time.Now()); wait > 0 {
+						select {
+						case <-throttle.context.Failure():
+							return
+						case <-throttle.context.Done():
+							return
+						case <-time.After(wait):
+						}
+					}
+					emitted = emitted[1:]
+				}
+
+				emitter.Emit(data)
+				emitted = append(emitted, time.Now())
+			}
+		}
+	}()
+
+	return readable
+}
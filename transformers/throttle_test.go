@@ -0,0 +1,64 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestThrottle(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Throttle(2, 50*time.Millisecond)
+				transformer.Attach(context)
+				start := time.Now()
+				next := transformer.Transform(in)
+
+				Convey("Then items are emitted respecting the rate limit", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+					So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 100*time.Millisecond)
+				})
+			})
+
+			Convey("When I close the context while the transformer is throttling", func() {
+				transformer := transformers.Throttle(1, time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+				So(<-next, ShouldEqual, 1)
+				context.Close(nil)
+
+				Convey("Then the transformer stops right away", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Throttle(2, time.Millisecond)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
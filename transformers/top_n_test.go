@@ -0,0 +1,70 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"math/rand"
+	"testing"
+)
+
+func TestTopN(t *testing.T) {
+	descending := func(a, b stream.T) bool { return a.(int) > b.(int) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			numbers := rand.Perm(100)
+			in, out := stream.New(len(numbers))
+			for _, n := range numbers {
+				out <- n
+			}
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.TopN(5, descending)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the result matches sorting the whole stream and taking the first n items", func() {
+					sorted := make([]stream.T, len(numbers))
+					for i, n := range numbers {
+						sorted[i] = n
+					}
+					stream.SortByFn(descending).Sort(sorted)
+
+					So(next.ReadAll(), ShouldResemble, sorted[:5])
+				})
+			})
+
+			Convey("When n is greater than the stream length", func() {
+				transformer := transformers.TopN(200, descending)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then all items are emitted in order", func() {
+					items := next.ReadAll()
+					So(len(items), ShouldEqual, 100)
+					So(items[0], ShouldEqual, 99)
+					So(items[99], ShouldEqual, 0)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.TopN(5, descending)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -1,10 +1,23 @@
 package transformers
 
 import (
+	"errors"
+	"fmt"
 	"github.com/drborges/rivers/stream"
 	"reflect"
+	"time"
 )
 
+func WithCapacity(capacity int) stream.Transformer {
+	return &Observer{
+		Capacity: capacity,
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			emitter.Emit(data)
+			return nil
+		},
+	}
+}
+
 func Filter(fn stream.PredicateFn) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
@@ -16,6 +29,93 @@ func Filter(fn stream.PredicateFn) stream.Transformer {
 	}
 }
 
+func ParallelFilter(workers int, fn stream.PredicateFn) stream.Transformer {
+	return &parallel{
+		workers: workers,
+		OnNext: func(data stream.T, emitter stream.Emitter) {
+			if fn(data) {
+				emitter.Emit(data)
+			}
+		},
+	}
+}
+
+// Same as Map but errors returned by fn are passed to report, or fail
+// the pipeline in case report is nil
+func MapE(fn stream.MapEFn, report func(data stream.T, err error)) stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			result, err := fn(data)
+			if err != nil {
+				if report == nil {
+					return err
+				}
+				report(data, err)
+				return nil
+			}
+			emitter.Emit(result)
+			return nil
+		},
+	}
+}
+
+// Same as MapE but retries fn according to the given policy before
+// giving up on an item
+func RetryBy(fn stream.MapEFn, policy stream.RetryPolicy, report func(data stream.T, err error)) stream.Transformer {
+	observer := &Observer{}
+	observer.OnNext = func(data stream.T, emitter stream.Emitter) error {
+		for failures := 1; ; failures++ {
+			result, err := fn(data)
+			if err == nil {
+				emitter.Emit(result)
+				return nil
+			}
+
+			if !policy.Allows(failures) {
+				if report == nil {
+					return err
+				}
+				report(data, err)
+				return nil
+			}
+
+			select {
+			case <-observer.context.Failure():
+				return nil
+			case <-observer.context.Done():
+				return nil
+			case <-time.After(policy.Delay(failures)):
+			}
+		}
+	}
+	return observer
+}
+
+func ParallelMap(workers int, fn stream.MapFn) stream.Transformer {
+	return &parallel{
+		workers: workers,
+		OnNext: func(data stream.T, emitter stream.Emitter) {
+			emitter.Emit(fn(data))
+		},
+	}
+}
+
+func ParallelMapOrdered(workers int, fn stream.MapFn) stream.Transformer {
+	return &orderedParallel{
+		workers: workers,
+		fn:      fn,
+	}
+}
+
+func GroupByKey(fn stream.MapFn) stream.Transformer {
+	return &groupBy{fn: fn}
+}
+
+// Emits at most n items within any given period of time
+func Throttle(n int, per time.Duration) stream.Transformer {
+	return &throttle{n: n, per: per}
+}
+
 func FindBy(fn stream.PredicateFn) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
@@ -28,7 +128,9 @@ func FindBy(fn stream.PredicateFn) stream.Transformer {
 	}
 }
 
-func TakeFirst(n int) stream.Transformer {
+// Takes the first n items ending the stream right after the nth
+// item is emitted, the remaining items are discarded
+func Take(n int) stream.Transformer {
 	taken := 0
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
@@ -38,11 +140,19 @@ func TakeFirst(n int) stream.Transformer {
 
 			emitter.Emit(data)
 			taken++
+
+			if taken >= n {
+				return stream.Done
+			}
 			return nil
 		},
 	}
 }
 
+func TakeFirst(n int) stream.Transformer {
+	return Take(n)
+}
+
 func DropFirst(n int) stream.Transformer {
 	dropped := 0
 	return &Observer{
@@ -58,6 +168,124 @@ func DropFirst(n int) stream.Transformer {
 	}
 }
 
+func Sample(n int) stream.Transformer {
+	count := 0
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if n <= 1 || count%n == 0 {
+				emitter.Emit(data)
+			}
+			count++
+			return nil
+		},
+	}
+}
+
+func Distinct() stream.Transformer {
+	return DistinctByLRU(0, func(data stream.T) stream.T { return data })
+}
+
+func DistinctBy(fn stream.MapFn) stream.Transformer {
+	return DistinctByLRU(0, fn)
+}
+
+func DistinctLRU(size int) stream.Transformer {
+	return DistinctByLRU(size, func(data stream.T) stream.T { return data })
+}
+
+// Drops items whose key was already seen among the last size distinct
+// keys, bounding memory usage on infinite streams
+func DistinctByLRU(size int, fn stream.MapFn) stream.Transformer {
+	seen := newLRU(size)
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if !seen.Add(fn(data)) {
+				emitter.Emit(data)
+			}
+			return nil
+		},
+	}
+}
+
+func DistinctConsecutive() stream.Transformer {
+	return DistinctConsecutiveBy(func(data stream.T) stream.T { return data })
+}
+
+func DistinctConsecutiveBy(fn stream.MapFn) stream.Transformer {
+	var last stream.T
+	emitted := false
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			key := fn(data)
+			if emitted && key == last {
+				return nil
+			}
+
+			emitter.Emit(data)
+			last = key
+			emitted = true
+			return nil
+		},
+	}
+}
+
+// Calls fn inline every n items, so it should be cheap
+// otherwise it will slow down the pipeline
+func OnProgress(every int, fn stream.ProgressFn) stream.Transformer {
+	count := 0
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			count++
+			if every > 0 && count%every == 0 {
+				fn(count)
+			}
+			emitter.Emit(data)
+			return nil
+		},
+	}
+}
+
+// Takes items while fn holds ending the stream on the first item
+// it does not, the remaining items are discarded
+func TakeWhile(fn stream.PredicateFn) stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if !fn(data) {
+				return stream.Done
+			}
+
+			emitter.Emit(data)
+			return nil
+		},
+	}
+}
+
+func DropWhile(fn stream.PredicateFn) stream.Transformer {
+	dropping := true
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if dropping && fn(data) {
+				return nil
+			}
+
+			dropping = false
+			emitter.Emit(data)
+			return nil
+		},
+	}
+}
+
+func Enumerate() stream.Transformer {
+	index := 0
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			emitter.Emit(stream.Indexed{Index: index, Value: data})
+			index++
+			return nil
+		},
+	}
+}
+
 func Map(fn stream.MapFn) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
@@ -67,6 +295,34 @@ func Map(fn stream.MapFn) stream.Transformer {
 	}
 }
 
+func Catch(fn stream.MapFn, fallback stream.T) stream.Transformer {
+	return CatchWith(fn, func(err error) stream.T { return fallback })
+}
+
+// Recovers from panics raised by fn for a single item, emitting the result
+// of the recover function instead of failing the whole pipeline
+func CatchWith(fn stream.MapFn, recoverFn stream.RecoverFn) stream.Transformer {
+	apply := func(data stream.T) (result stream.T) {
+		defer func() {
+			if r := recover(); r != nil {
+				err := errors.New(fmt.Sprintf("Recovered from %v", r))
+				if e, ok := r.(error); ok {
+					err = e
+				}
+				result = recoverFn(err)
+			}
+		}()
+		return fn(data)
+	}
+
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			emitter.Emit(apply(data))
+			return nil
+		},
+	}
+}
+
 func OnData(fn stream.OnDataFn) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
@@ -88,6 +344,78 @@ func Reduce(acc stream.T, fn stream.ReduceFn) stream.Transformer {
 	}
 }
 
+// Reduces items sharing the same key into their own accumulator, emitting
+// a stream.KeyValue per key in the order keys were first seen once the
+// stream is completed
+func ReduceBy(keyFn stream.MapFn, seed stream.T, fn stream.ReduceFn) stream.Transformer {
+	var keys []stream.T
+	accs := make(map[stream.T]stream.T)
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			key := keyFn(data)
+			acc, exists := accs[key]
+			if !exists {
+				acc = seed
+				keys = append(keys, key)
+			}
+			accs[key] = fn(acc, data)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			for _, key := range keys {
+				emitter.Emit(stream.KeyValue{Key: key, Value: accs[key]})
+			}
+		},
+	}
+}
+
+// Same as Reduce but emits every intermediate accumulation
+func Scan(acc stream.T, fn stream.ReduceFn) stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			acc = fn(acc, data)
+			emitter.Emit(acc)
+			return nil
+		},
+	}
+}
+
+func Reduce1(fn stream.ReduceFn) stream.Transformer {
+	var acc stream.T
+	seeded := false
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if !seeded {
+				acc = data
+				seeded = true
+				return nil
+			}
+			acc = fn(acc, data)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if seeded {
+				emitter.Emit(acc)
+			}
+		},
+	}
+}
+
+func TopN(n int, fn stream.SortByFn) stream.Transformer {
+	top := &boundedHeap{size: n, by: fn}
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			top.Add(data)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			for _, data := range top.Sorted() {
+				emitter.Emit(data)
+			}
+		},
+	}
+}
+
 func Flatten() stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
@@ -104,6 +432,32 @@ func Flatten() stream.Transformer {
 	}
 }
 
+func FlattenDeep() stream.Transformer {
+	var flatten func(data stream.T, emitter stream.Emitter)
+	flatten = func(data stream.T, emitter stream.Emitter) {
+		dv := reflect.ValueOf(data)
+		if dv.Kind() == reflect.Ptr && dv.Elem().Kind() == reflect.Slice {
+			dv = dv.Elem()
+		}
+
+		if dv.Kind() != reflect.Slice {
+			emitter.Emit(data)
+			return
+		}
+
+		for i := 0; i < dv.Len(); i++ {
+			flatten(dv.Index(i).Interface(), emitter)
+		}
+	}
+
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			flatten(data, emitter)
+			return nil
+		},
+	}
+}
+
 func Batch(size int) stream.Transformer {
 	return BatchBy(&batch{size: size})
 }
@@ -125,6 +479,83 @@ func BatchBy(batch stream.Batch) stream.Transformer {
 	}
 }
 
+// Same as Batch, also flushing the partial batch when the context is
+// closed without errors (Done). The partial batch is discarded in case
+// the context fails
+func BatchFlushOnClose(size int) stream.Transformer {
+	batch := &batch{size: size}
+	commit := func(emitter stream.Emitter) {
+		if !batch.Empty() {
+			batch.Commit(emitter)
+		}
+	}
+
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			batch.Add(data)
+			if batch.Full() {
+				batch.Commit(emitter)
+			}
+			return nil
+		},
+		OnCompleted: commit,
+		OnDone:      commit,
+	}
+}
+
+// Emits non overlapping windows of the given size,
+// discarding any trailing partial window
+func Window(size int) stream.Transformer {
+	return slidingWindow(size, size, false)
+}
+
+// Same as SlidingWindow
+func WindowEvery(size, slide int) stream.Transformer {
+	return SlidingWindow(size, slide)
+}
+
+// Emits windows of the given size starting every step items, discarding
+// any trailing partial window. Steps below 1 are treated as 1 and sizes
+// below 1 panic.
+func SlidingWindow(size, step int) stream.Transformer {
+	return slidingWindow(size, step, false)
+}
+
+func SlidingWindowWithTail(size, step int) stream.Transformer {
+	return slidingWindow(size, step, true)
+}
+
+func slidingWindow(size, step int, tail bool) stream.Transformer {
+	if size <= 0 {
+		panic("Window size must be positive")
+	}
+
+	if step <= 0 {
+		step = 1
+	}
+
+	window := &window{size: size, step: step}
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			window.Add(data, emitter)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if tail {
+				window.Flush(emitter)
+			}
+		},
+	}
+}
+
+// Same as Each but errors returned by fn are passed to report, or fail
+// the pipeline in case report is nil. Failed items are not emitted.
+func EachE(fn stream.EachEFn, report func(data stream.T, err error)) stream.Transformer {
+	return MapE(func(data stream.T) (stream.T, error) {
+		return data, fn(data)
+	}, report)
+}
+
 func Each(fn stream.EachFn) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
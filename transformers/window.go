@@ -0,0 +1,50 @@
+package transformers
+
+import "github.com/drborges/rivers/stream"
+
+type window struct {
+	size    int
+	step    int
+	items   []stream.T
+	skip    int
+	pending int
+}
+
+func (window *window) Add(data stream.T, emitter stream.Emitter) {
+	if window.skip > 0 {
+		window.skip--
+		return
+	}
+
+	window.items = append(window.items, data)
+	window.pending++
+
+	if len(window.items) < window.size {
+		return
+	}
+
+	window.commit(emitter)
+
+	if window.step >= window.size {
+		window.skip = window.step - window.size
+		window.items = nil
+		return
+	}
+
+	window.items = append([]stream.T{}, window.items[window.step:]...)
+}
+
+// Emits the trailing partial window in case it holds
+// items not yet emitted by a previous window
+func (window *window) Flush(emitter stream.Emitter) {
+	if window.pending > 0 {
+		window.commit(emitter)
+	}
+}
+
+func (window *window) commit(emitter stream.Emitter) {
+	items := make([]stream.T, len(window.items))
+	copy(items, window.items)
+	window.pending = 0
+	emitter.Emit(items)
+}
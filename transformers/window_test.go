@@ -0,0 +1,137 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply a tumbling window transformer to the stream", func() {
+				transformer := transformers.Window(2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only full non overlapping windows are emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{1, 2},
+						[]stream.T{3, 4},
+					})
+				})
+			})
+
+			Convey("When I apply a window transformer sliding every other item", func() {
+				transformer := transformers.WindowEvery(3, 2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only full overlapping windows are emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{1, 2, 3},
+						[]stream.T{3, 4, 5},
+					})
+				})
+			})
+
+			Convey("When I apply an overlapping window transformer to the stream", func() {
+				transformer := transformers.SlidingWindow(3, 1)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then windows advance by the given step", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{1, 2, 3},
+						[]stream.T{2, 3, 4},
+						[]stream.T{3, 4, 5},
+					})
+				})
+			})
+
+			Convey("When I apply an overlapping window transformer with tail to the stream", func() {
+				transformer := transformers.SlidingWindowWithTail(3, 2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the trailing items are emitted in a shorter window", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{1, 2, 3},
+						[]stream.T{3, 4, 5},
+					})
+				})
+			})
+
+			Convey("When I apply a non overlapping window transformer with tail to the stream", func() {
+				transformer := transformers.SlidingWindowWithTail(2, 2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the result is the same as a batch", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{1, 2},
+						[]stream.T{3, 4},
+						[]stream.T{5},
+					})
+				})
+			})
+
+			Convey("When I apply a window transformer with a step greater than the size", func() {
+				transformer := transformers.SlidingWindow(2, 3)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items in between windows are skipped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{1, 2},
+						[]stream.T{4, 5},
+					})
+				})
+			})
+
+			Convey("When I apply a window larger than the stream", func() {
+				transformer := transformers.SlidingWindow(10, 1)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no window is emitted", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When I create windows of non positive sizes", func() {
+				Convey("Then it panics", func() {
+					So(func() { transformers.Window(0) }, ShouldPanicWith, "Window size must be positive")
+					So(func() { transformers.WindowEvery(-1, 1) }, ShouldPanicWith, "Window size must be positive")
+					So(func() { transformers.SlidingWindowWithTail(0, 1) }, ShouldPanicWith, "Window size must be positive")
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.SlidingWindow(2, 1)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,22 @@
+package typed
+
+import "github.com/drborges/rivers/stream"
+
+// Converts the given typed items into untyped stream data
+func ToData[V any](items ...V) []stream.T {
+	data := make([]stream.T, len(items))
+	for i, item := range items {
+		data[i] = item
+	}
+	return data
+}
+
+// Converts untyped stream data back into typed items, panicking
+// in case any of the items is not of the expected type
+func As[V any](data []stream.T) []V {
+	items := make([]V, len(data))
+	for i, item := range data {
+		items[i] = item.(V)
+	}
+	return items
+}
@@ -0,0 +1,27 @@
+package typed_test
+
+import (
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/typed"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestData(t *testing.T) {
+	Convey("Given I have typed items", t, func() {
+		items := []string{"a", "b"}
+
+		Convey("When I convert them into untyped data and back", func() {
+			data := typed.ToData(items...)
+
+			Convey("Then I get the original items", func() {
+				So(data, ShouldResemble, []stream.T{"a", "b"})
+				So(typed.As[string](data), ShouldResemble, items)
+			})
+		})
+
+		Convey("Then converting data of a different type panics", func() {
+			So(func() { typed.As[int](typed.ToData(items...)) }, ShouldPanic)
+		})
+	})
+}
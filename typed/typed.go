@@ -0,0 +1,90 @@
+package typed
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+)
+
+// Type safe view over an untyped rivers pipeline whose
+// data is known to be of type T
+type Pipeline[T any] struct {
+	pipeline *rivers.Pipeline
+}
+
+func Of[T any](items ...T) *Pipeline[T] {
+	return From[T](rivers.FromData(ToData(items...)...))
+}
+
+func FromSlice[T any](items []T) *Pipeline[T] {
+	return Of(items...)
+}
+
+func FromChan[T any](ch <-chan T) *Pipeline[T] {
+	return From[T](rivers.FromAnyChannel(ch))
+}
+
+// Adapts an untyped pipeline into a typed one
+func From[T any](pipeline *rivers.Pipeline) *Pipeline[T] {
+	return &Pipeline[T]{pipeline}
+}
+
+func Map[T, U any](pipeline *Pipeline[T], fn func(T) U) *Pipeline[U] {
+	return From[U](pipeline.pipeline.Map(func(data stream.T) stream.T {
+		return fn(data.(T))
+	}))
+}
+
+func FlatMap[T, U any](pipeline *Pipeline[T], fn func(T) []U) *Pipeline[U] {
+	return From[U](pipeline.pipeline.FlatMap(func(data stream.T) stream.T {
+		return fn(data.(T))
+	}))
+}
+
+func Reduce[T, U any](pipeline *Pipeline[T], acc U, fn func(U, T) U) *Pipeline[U] {
+	return From[U](pipeline.pipeline.Reduce(acc, func(acc, data stream.T) stream.T {
+		return fn(acc.(U), data.(T))
+	}))
+}
+
+func Filter[T any](pipeline *Pipeline[T], fn func(T) bool) *Pipeline[T] {
+	return pipeline.Filter(fn)
+}
+
+func (pipeline *Pipeline[T]) Filter(fn func(T) bool) *Pipeline[T] {
+	return From[T](pipeline.pipeline.Filter(func(data stream.T) bool {
+		return fn(data.(T))
+	}))
+}
+
+func (pipeline *Pipeline[T]) Each(fn func(T)) *Pipeline[T] {
+	return From[T](pipeline.pipeline.Each(func(data stream.T) {
+		fn(data.(T))
+	}))
+}
+
+func (pipeline *Pipeline[T]) TakeFirst(n int) *Pipeline[T] {
+	return From[T](pipeline.pipeline.TakeFirst(n))
+}
+
+// Returns the underlying untyped pipeline
+func (pipeline *Pipeline[T]) Untyped() *rivers.Pipeline {
+	return pipeline.pipeline
+}
+
+func (pipeline *Pipeline[T]) Collect() ([]T, error) {
+	data, err := pipeline.pipeline.Collect()
+	return As[T](data), err
+}
+
+func (pipeline *Pipeline[T]) CollectFirst() (T, error) {
+	var first T
+	data, err := pipeline.pipeline.CollectFirst()
+	if data != nil {
+		first = data.(T)
+	}
+	return first, err
+}
+
+func (pipeline *Pipeline[T]) Drain() error {
+	return pipeline.pipeline.Drain()
+}
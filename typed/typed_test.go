@@ -0,0 +1,108 @@
+package typed_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/typed"
+	. "github.com/smartystreets/goconvey/convey"
+	"strconv"
+	"testing"
+)
+
+func TestTypedPipeline(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+	double := func(n int) int { return n * 2 }
+
+	Convey("Given I have a typed pipeline of ints", t, func() {
+		pipeline := typed.Of(1, 2, 3, 4)
+
+		Convey("When I filter and map the data", func() {
+			data, err := typed.Map(pipeline.Filter(isEven), strconv.Itoa).Collect()
+
+			Convey("Then I get the typed results", func() {
+				So(err, ShouldBeNil)
+				So(data, ShouldResemble, []string{"2", "4"})
+			})
+		})
+
+		Convey("When I filter the data with the package level function", func() {
+			data, err := typed.Filter(pipeline, isEven).Collect()
+
+			Convey("Then only the matching items are collected", func() {
+				So(err, ShouldBeNil)
+				So(data, ShouldResemble, []int{2, 4})
+			})
+		})
+
+		Convey("When I flat map the data", func() {
+			data, err := typed.FlatMap(pipeline, func(n int) []int { return []int{n, n} }).Collect()
+
+			Convey("Then the resulting slices are flattened", func() {
+				So(err, ShouldBeNil)
+				So(data, ShouldResemble, []int{1, 1, 2, 2, 3, 3, 4, 4})
+			})
+		})
+
+		Convey("When I reduce the data", func() {
+			sum, err := typed.Reduce(pipeline, "", func(acc string, n int) string {
+				return acc + strconv.Itoa(n)
+			}).CollectFirst()
+
+			Convey("Then I get the typed accumulated value", func() {
+				So(err, ShouldBeNil)
+				So(sum, ShouldEqual, "1234")
+			})
+		})
+
+		Convey("When I convert it back to an untyped pipeline", func() {
+			data, err := typed.Map(pipeline, double).Untyped().Collect()
+
+			Convey("Then I get the untyped stream data", func() {
+				So(err, ShouldBeNil)
+				So(data, ShouldResemble, []stream.T{2, 4, 6, 8})
+			})
+		})
+	})
+
+	Convey("Given I have an untyped pipeline", t, func() {
+		pipeline := rivers.FromData("a", "b")
+
+		Convey("When I adapt it into a typed pipeline", func() {
+			first, err := typed.From[string](pipeline).CollectFirst()
+
+			Convey("Then I can read typed data from it", func() {
+				So(err, ShouldBeNil)
+				So(first, ShouldEqual, "a")
+			})
+		})
+	})
+
+	Convey("Given I have a typed channel", t, func() {
+		ch := make(chan string, 2)
+		ch <- "a"
+		ch <- "b"
+		close(ch)
+
+		Convey("When I collect the data from a pipeline fed by it", func() {
+			data, err := typed.FromChan(ch).Collect()
+
+			Convey("Then I get the typed channel values", func() {
+				So(err, ShouldBeNil)
+				So(data, ShouldResemble, []string{"a", "b"})
+			})
+		})
+	})
+
+	Convey("Given I have an empty typed pipeline", t, func() {
+		pipeline := typed.FromSlice([]int{})
+
+		Convey("When I collect the first item", func() {
+			first, err := pipeline.CollectFirst()
+
+			Convey("Then I get the zero value", func() {
+				So(err, ShouldBeNil)
+				So(first, ShouldEqual, 0)
+			})
+		})
+	})
+}